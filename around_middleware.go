@@ -0,0 +1,69 @@
+package gommand
+
+// AroundMiddleware is used to wrap the command function. Calling next runs the next around middleware, or the command function if this is the innermost one.
+// This allows you to run code before and after the command, time it, recover from panics or change the error which is returned.
+// next should be called at most once. If it is not called, the command will not run and the error returned will be passed to the error handler.
+// If the command panics, next returns a *PanicError.
+type AroundMiddleware = func(ctx *Context, next func() error) error
+
+// AfterHook is called after the command function has ran with the error which was returned (after the around middleware).
+type AfterHook = func(ctx *Context, err error)
+
+// AroundMiddlewareProvider is an optional interface which commands and categories can implement to provide around middleware.
+type AroundMiddlewareProvider interface {
+	GetAroundMiddleware() []AroundMiddleware
+}
+
+// AfterHooksProvider is an optional interface which commands and categories can implement to provide after hooks.
+type AfterHooksProvider interface {
+	GetAfterHooks() []AfterHook
+}
+
+// Runs the function within the around middleware and then runs the after hooks.
+// Around middleware runs from outermost to innermost (the router, then the category, then any command groups, then the command) and after hooks run in the opposite order.
+func runWrapped(ctx *Context, cat CategoryInterface, c CommandInterface, f func() error) error {
+	// Get the around middleware.
+	middleware := make([]AroundMiddleware, 0)
+	middleware = append(middleware, ctx.Router.aroundMiddleware...)
+	if p, ok := cat.(AroundMiddlewareProvider); ok {
+		middleware = append(middleware, p.GetAroundMiddleware()...)
+	}
+	for _, g := range ctx.Groups {
+		middleware = append(middleware, g.AroundMiddleware...)
+	}
+	if p, ok := c.(AroundMiddlewareProvider); ok {
+		middleware = append(middleware, p.GetAroundMiddleware()...)
+	}
+
+	// Chain the middleware together and run it.
+	call := f
+	for i := len(middleware) - 1; i >= 0; i-- {
+		m := middleware[i]
+		next := call
+		call = func() error {
+			return m(ctx, next)
+		}
+	}
+	err := call()
+
+	// Run the after hooks.
+	if p, ok := c.(AfterHooksProvider); ok {
+		for _, v := range p.GetAfterHooks() {
+			v(ctx, err)
+		}
+	}
+	for i := len(ctx.Groups) - 1; i >= 0; i-- {
+		for _, v := range ctx.Groups[i].AfterHooks {
+			v(ctx, err)
+		}
+	}
+	if p, ok := cat.(AfterHooksProvider); ok {
+		for _, v := range p.GetAfterHooks() {
+			v(ctx, err)
+		}
+	}
+	for _, v := range ctx.Router.afterHooks {
+		v(ctx, err)
+	}
+	return err
+}
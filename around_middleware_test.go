@@ -0,0 +1,117 @@
+package gommand
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestAroundMiddleware is used to test the ordering of around middleware and after hooks.
+func TestAroundMiddleware(t *testing.T) {
+	events := make([]string, 0)
+	around := func(name string) AroundMiddleware {
+		return func(ctx *Context, next func() error) error {
+			events = append(events, name+" before")
+			err := next()
+			events = append(events, name+" after")
+			return err
+		}
+	}
+	after := func(name string) AfterHook {
+		return func(ctx *Context, err error) {
+			if err == nil {
+				events = append(events, name+" hook")
+			} else {
+				events = append(events, name+" hook "+err.Error())
+			}
+		}
+	}
+	cat := &Category{
+		Name:             "cat",
+		AroundMiddleware: []AroundMiddleware{around("category")},
+		AfterHooks:       []AfterHook{after("category")},
+	}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Middleware: []Middleware{func(ctx *Context) error {
+			events = append(events, "middleware")
+			return nil
+		}},
+		AroundMiddleware: []AroundMiddleware{around("router 1"), around("router 2")},
+		AfterHooks:       []AfterHook{after("router")},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	r.SetCommand(&Command{
+		Name:             "cmd",
+		Category:         cat,
+		AroundMiddleware: []AroundMiddleware{around("command")},
+		AfterHooks:       []AfterHook{after("command")},
+		Function: func(ctx *Context) error {
+			events = append(events, "function")
+			return errors.New("failed")
+		},
+	})
+	group := &CommandGroup{
+		Name:             "group",
+		Category:         cat,
+		AroundMiddleware: []AroundMiddleware{around("group")},
+		AfterHooks:       []AfterHook{after("group")},
+		subcommands: map[string]CommandInterface{
+			"sub": &Command{
+				Name:             "sub",
+				AroundMiddleware: []AroundMiddleware{around("sub")},
+				Function: func(ctx *Context) error {
+					events = append(events, "function")
+					return nil
+				},
+			},
+		},
+	}
+	r.SetCommand(group)
+	r.SetCommand(&Command{
+		Name: "recover",
+		AroundMiddleware: []AroundMiddleware{func(ctx *Context, next func() error) error {
+			if _, ok := next().(*PanicError); ok {
+				events = append(events, "recovered")
+				return nil
+			}
+			return nil
+		}},
+		Function: func(ctx *Context) error {
+			panic("oh no")
+		},
+	})
+
+	tests := []struct {
+		content string
+		events  []string
+	}{
+		{"%cmd", []string{
+			"middleware", "router 1 before", "router 2 before", "category before", "command before", "function",
+			"command after", "category after", "router 2 after", "router 1 after",
+			"command hook failed", "category hook failed", "router hook failed",
+		}},
+		{"%group sub", []string{
+			"middleware", "router 1 before", "router 2 before", "category before", "group before",
+			"sub before", "function", "sub after",
+			"group after", "category after", "router 2 after", "router 1 after",
+			"group hook", "category hook", "router hook",
+		}},
+		{"%recover", []string{"middleware", "router 1 before", "router 2 before", "recovered", "router 2 after", "router 1 after", "router hook"}},
+	}
+	for _, v := range tests {
+		events = events[:0]
+		lastErr = nil
+		r.CommandProcessor(nil, 0, mockMessage(v.content), true)
+		if strings.Join(events, ", ") != strings.Join(v.events, ", ") {
+			t.Fatalf("%s: expected:\n%s\ngot:\n%s", v.content, strings.Join(v.events, ", "), strings.Join(events, ", "))
+		}
+	}
+	if lastErr != nil {
+		t.Fatal("the panic should have been recovered, got", lastErr)
+	}
+}
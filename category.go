@@ -17,6 +17,9 @@ type Category struct {
 	Cooldown             Cooldown              `json:"cooldown"`
 	PermissionValidators []PermissionValidator `json:"-"`
 	Middleware           []Middleware          `json:"-"`
+	AroundMiddleware     []AroundMiddleware    `json:"-"`
+	AfterHooks           []AfterHook           `json:"-"`
+	Hidden               bool                  `json:"hidden"`
 }
 
 // GetName is used to get the name of the category.
@@ -49,3 +52,18 @@ func (c *Category) GetMiddleware() []Middleware {
 func (c *Category) GetCooldown() Cooldown {
 	return c.Cooldown
 }
+
+// GetAroundMiddleware is used to get the around middleware of the category.
+func (c *Category) GetAroundMiddleware() []AroundMiddleware {
+	return c.AroundMiddleware
+}
+
+// GetAfterHooks is used to get the after hooks of the category.
+func (c *Category) GetAfterHooks() []AfterHook {
+	return c.AfterHooks
+}
+
+// GetHidden is used to get if the category is hidden.
+func (c *Category) GetHidden() bool {
+	return c.Hidden
+}
@@ -0,0 +1,144 @@
+package gommand
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ChainingConfig is used to configure running several commands in one message.
+// Commands can be chained with ";" (such as "!a ; !b"), which runs them one after another, or piped with "|" (such as "!search foo | !translate fr"), which gives the text the first command replies with to the next.
+type ChainingConfig struct {
+	// MaxCommands is the maximum number of commands which can be in one message. 0 will default to 5.
+	MaxCommands int
+}
+
+// TextPipeProvider is an optional interface which commands can implement to declare if they can be piped.
+// Commands which are not this interface cannot be piped from or into.
+type TextPipeProvider interface {
+	// GetProducesText is used to get if the text the command replies with can be piped into another command.
+	GetProducesText() bool
+
+	// GetConsumesText is used to get if the command can be given the text from another command with ctx.Input.
+	GetConsumesText() bool
+}
+
+// Defines a command which is part of a chain.
+type chainPart struct {
+	// The invocation after the prefix.
+	content string
+
+	// Defines if the output of the previous command is piped into this one.
+	piped bool
+}
+
+// Splits the content after the prefix into the commands it runs.
+// A separator only starts a new command if the text after it starts with the prefix used for the first command, meaning arguments containing ";" or "|" are not split.
+func splitChain(ctx *Context, content string) []chainPart {
+	parts := []chainPart{}
+	if ctx.Prefix == "" {
+		return append(parts, chainPart{content: strings.Trim(content, " ")})
+	}
+	last := 0
+	piped := false
+	var quote byte
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '`':
+			quote = c
+		case ';', '|':
+			// Check the prefix is used after the separator.
+			rest := strings.TrimLeft(content[i+1:], " ")
+			if !strings.HasPrefix(rest, ctx.Prefix) {
+				continue
+			}
+
+			// Start the next command after the prefix.
+			parts = append(parts, chainPart{content: strings.Trim(content[last:i], " "), piped: piped})
+			piped = c == '|'
+			last = len(content) - len(rest) + len(ctx.Prefix)
+			i = last - 1
+		}
+	}
+	return append(parts, chainPart{content: strings.Trim(content[last:], " "), piped: piped})
+}
+
+// Runs the commands in a chain one after another using the command table given, stopping if one of them errors.
+func (r *Router) runChain(ctx *Context, cmds *commandTable, parts []chainPart) {
+	max := 5
+	if r.chaining.MaxCommands != 0 {
+		max = r.chaining.MaxCommands
+	}
+	if len(parts) > max {
+		r.errorHandler(ctx, &ChainTooLong{err: ctx.Translate(MessageChainTooLong, "max", strconv.Itoa(max))})
+		return
+	}
+	input := ""
+	for i, part := range parts {
+		partCtx := &Context{
+			ShardID:          ctx.ShardID,
+			Prefix:           ctx.Prefix,
+			Message:          ctx.Message,
+			BotUser:          ctx.BotUser,
+			Router:           r,
+			Session:          ctx.Session,
+			Args:             []interface{}{},
+			MiddlewareParams: map[string]interface{}{},
+			State:            ctx.State,
+			Input:            input,
+			piped:            part.piped,
+		}
+		partCtx.WaitManager = &WaitManager{ctx: partCtx}
+		if i != len(parts)-1 && parts[i+1].piped {
+			partCtx.capture = &[]string{}
+		}
+		span := ctx.StartSpan("chain_command")
+		span.SetAttribute("index", i)
+		partCtx.span = span
+		err := r.runInvocation(partCtx, cmds, strings.NewReader(part.content))
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		if err != nil {
+			// Make sure the error handler can reply.
+			partCtx.capture = nil
+			r.errorHandler(partCtx, err)
+			return
+		}
+		input = ""
+		if partCtx.capture != nil {
+			input = strings.Join(*partCtx.capture, "\n")
+		}
+	}
+}
+
+// Checks the command can be used in the position of the chain it is in.
+func checkPipe(ctx *Context, c CommandInterface) error {
+	if ctx.capture == nil && !ctx.piped {
+		return nil
+	}
+	if _, ok := c.(*CommandGroup); ok {
+		// The group will check the sub-command it runs.
+		return nil
+	}
+	p, _ := c.(TextPipeProvider)
+	if ctx.capture != nil && (p == nil || !p.GetProducesText()) {
+		return cannotProduceText(ctx)
+	}
+	if ctx.piped && (p == nil || !p.GetConsumesText()) {
+		return &CommandNotPipeable{err: ctx.Translate(MessageCommandCannotConsumeText, "command", ctx.CommandPath())}
+	}
+	return nil
+}
+
+// Returns the error for a command whose output cannot be piped into another command.
+func cannotProduceText(ctx *Context) error {
+	return &CommandNotPipeable{err: ctx.Translate(MessageCommandCannotProduceText, "command", ctx.CommandPath())}
+}
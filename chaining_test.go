@@ -0,0 +1,108 @@
+package gommand_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/gommand"
+)
+
+// TestChaining is used to test chaining commands and piping the output of one command into another.
+func TestChaining(t *testing.T) {
+	var prefixChecks int32
+	r, h := newTestHarness(&gommand.RouterConfig{
+		PrefixCheck: func(ctx *gommand.Context, r io.ReadSeeker) bool {
+			atomic.AddInt32(&prefixChecks, 1)
+			return gommand.StaticPrefix("%")(ctx, r)
+		},
+		Chaining: &gommand.ChainingConfig{MaxCommands: 3},
+	})
+	r.SetCommand(&gommand.Command{
+		Name:         "echo",
+		ProducesText: true,
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Reply(ctx.RawArgs)
+			return err
+		},
+	})
+	r.SetCommand(&gommand.Command{
+		Name:         "upper",
+		ProducesText: true,
+		ConsumesText: true,
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Reply(&disgord.Embed{Title: strings.ToUpper(strings.Trim(ctx.Input+" "+ctx.RawArgs, " "))})
+			return err
+		},
+	})
+	r.SetCommand(replyCommand("ping", "Pong!"))
+	r.SetCommand(&gommand.Command{
+		Name: "fail",
+		Function: func(ctx *gommand.Context) error {
+			return errors.New("Failed.")
+		},
+	})
+	r.SetCommand(&gommand.Command{
+		Name:         "menu",
+		ProducesText: true,
+		Function: func(ctx *gommand.Context) error {
+			return ctx.DisplayEmbedMenu(gommand.NewEmbedMenu(&disgord.Embed{Title: "menu"}, ctx))
+		},
+	})
+	r.SetCommand(&gommand.Command{
+		Name:         "ask",
+		ProducesText: true,
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Confirm("Are you sure?", nil)
+			return err
+		},
+	})
+	c := h.Conversation(t, 30, 4)
+
+	c.Send("%echo hi ; %ping")
+	c.Expect("hi")
+	c.Expect("Pong!")
+	c.Send("%echo hello | %upper world | %upper")
+	c.Expect("HELLO WORLD")
+	c.Send("%echo a;b | c \"x ; %ping\"")
+	c.Expect("a;b | c \"x ; %ping\"")
+	c.Send("%ping | %upper")
+	c.Expect("The output of the command \"ping\" cannot be piped into another command.")
+	c.Send("%echo x | %ping")
+	c.Expect("The command \"ping\" cannot have text piped into it.")
+	c.Send("%fail ; %ping")
+	c.Expect("Failed.")
+	c.Send("%ping ; %ping ; %ping ; %ping")
+	c.Expect("You can only run up to 3 commands in one message.")
+	c.Send("%menu | %upper")
+	c.Expect("MENU")
+	c.Send("%ask | %upper")
+	c.Expect("The output of the command \"ask\" cannot be piped into another command.")
+	if n := atomic.LoadInt32(&prefixChecks); n != 9 {
+		t.Fatal("expected the prefix to be checked once for each message, got", n)
+	}
+	c.AssertTranscript(
+		"user: %echo hi ; %ping",
+		"bot: hi",
+		"bot: Pong!",
+		"user: %echo hello | %upper world | %upper",
+		"bot: [HELLO WORLD]",
+		"user: %echo a;b | c \"x ; %ping\"",
+		"bot: a;b | c \"x ; %ping\"",
+		"user: %ping | %upper",
+		"bot: The output of the command \"ping\" cannot be piped into another command.",
+		"user: %echo x | %ping",
+		"bot: The command \"ping\" cannot have text piped into it.",
+		"user: %fail ; %ping",
+		"bot: Failed.",
+		"user: %ping ; %ping ; %ping ; %ping",
+		"bot: You can only run up to 3 commands in one message.",
+		"user: %menu | %upper",
+		"bot: [MENU]",
+		"user: %ask | %upper",
+		"bot: The output of the command \"ask\" cannot be piped into another command.",
+	)
+}
@@ -0,0 +1,62 @@
+package gommand
+
+import (
+	"context"
+	"time"
+)
+
+// Timer is a timer created by a Clock. *time.Timer implements this interface.
+type Timer interface {
+	// Stop is used to stop the timer. This returns false if the timer already expired or was stopped.
+	Stop() bool
+
+	// Reset is used to change the timer to expire after the duration specified. This returns true if the timer was active.
+	Reset(d time.Duration) bool
+}
+
+// Clock is used to define the interface which is used to get the time and schedule functions.
+// This allows time-based functionality to be tested without waiting for real time to pass.
+type Clock interface {
+	// Now is used to get the current time.
+	Now() time.Time
+
+	// AfterFunc is used to call the function in its own goroutine after the duration has passed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// The clock which uses the system time.
+type systemClock struct{}
+
+// Now is used to get the current system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc is used to call time.AfterFunc.
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// SystemClock is the clock which uses the system time. This is the default clock used by the router.
+var SystemClock Clock = systemClock{}
+
+// Gets the clock which the router uses. This is safe to call on a nil router.
+func (r *Router) clock() Clock {
+	if r == nil || r.Clock == nil {
+		return SystemClock
+	}
+	return r.Clock
+}
+
+// Creates a context which is cancelled once the duration has passed on the clock.
+func withClockTimeout(clock Clock, parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if clock == SystemClock {
+		return context.WithTimeout(parent, d)
+	}
+	ctx, cancel := context.WithCancel(parent)
+	timer := clock.AfterFunc(d, cancel)
+	return ctx, func() {
+		timer.Stop()
+		cancel()
+	}
+}
@@ -0,0 +1,225 @@
+package gommand
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// CollectorEndReason is the reason why a collector stopped collecting.
+type CollectorEndReason uint8
+
+const (
+	// CollectorLimitReached means the maximum number of events was collected.
+	CollectorLimitReached CollectorEndReason = iota
+
+	// CollectorTimedOut means the collector reached its timeout.
+	CollectorTimedOut
+
+	// CollectorIdle means nothing was collected within the idle timeout.
+	CollectorIdle
+
+	// CollectorStopped means the collector was stopped with Stop.
+	CollectorStopped
+)
+
+// Handles the shared logic between collectors.
+type collectorInternals struct {
+	lock    sync.Mutex
+	stopped bool
+	stream  *EventStream
+}
+
+// Starts collecting the event. The end function is called when collecting stops.
+func (c *collectorInternals) start(s disgord.Session, clock Clock, EventName string, Max int, Timeout, IdleTimeout time.Duration, CheckFunc func(evt interface{}) bool, Collect func(evt interface{}), End func(reason CollectorEndReason)) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Create the context for the timeout.
+	if clock == nil {
+		clock = SystemClock
+	}
+	ctx := context.Background()
+	cancel := func() {}
+	if Timeout > 0 {
+		ctx, cancel = withClockTimeout(clock, ctx, Timeout)
+	}
+
+	// Start collecting the events.
+	stream, err := collectEvents(ctx, s, Max, func(_ disgord.Session, evt interface{}) bool {
+		return CheckFunc(evt)
+	}, EventName)
+	if err != nil {
+		cancel()
+		return err
+	}
+	c.stream = stream
+	c.stopped = false
+
+	// Handle the events until the stream ends.
+	go func() {
+		defer cancel()
+		var idle chan struct{}
+		var idleTimer Timer
+		if IdleTimeout > 0 {
+			idle = make(chan struct{}, 1)
+			idleTimer = clock.AfterFunc(IdleTimeout, func() {
+				idle <- struct{}{}
+			})
+			defer idleTimer.Stop()
+		}
+		count := 0
+		for {
+			select {
+			case evt, ok := <-stream.Events():
+				if !ok {
+					c.lock.Lock()
+					stopped := c.stopped
+					c.lock.Unlock()
+					reason := CollectorTimedOut
+					if stopped {
+						reason = CollectorStopped
+					} else if Max > 0 && count >= Max {
+						reason = CollectorLimitReached
+					}
+					End(reason)
+					return
+				}
+				count++
+				Collect(evt)
+				if idleTimer != nil {
+					if !idleTimer.Stop() {
+						<-idle
+					}
+					idleTimer.Reset(IdleTimeout)
+				}
+			case <-idle:
+				stream.Cancel()
+				for range stream.Events() {
+					// Drain anything which was sent before the stream was cancelled.
+				}
+				End(CollectorIdle)
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stops collecting.
+func (c *collectorInternals) stop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.stream != nil {
+		c.stopped = true
+		c.stream.Cancel()
+	}
+}
+
+// MessageCollector is used to collect messages in a channel. This can be used outside of commands.
+type MessageCollector struct {
+	// ChannelID is the ID of the channel messages are collected from.
+	ChannelID disgord.Snowflake
+
+	// Filter is used to filter the messages which are collected. This can be nil.
+	Filter func(msg *disgord.Message) bool
+
+	// Max is the maximum number of messages to collect. 0 means there is no limit.
+	Max int
+
+	// Timeout is the maximum time the collector will collect for. 0 means there is no timeout.
+	Timeout time.Duration
+
+	// IdleTimeout is the maximum time between messages before the collector stops. 0 means there is no idle timeout.
+	IdleTimeout time.Duration
+
+	// Clock is used for the timeouts. nil will default to SystemClock.
+	Clock Clock
+
+	// OnCollect is called with each message collected. This can be nil.
+	OnCollect func(msg *disgord.Message)
+
+	// OnEnd is called with all of the messages collected and the reason when the collector stops. This can be nil.
+	OnEnd func(collected []*disgord.Message, reason CollectorEndReason)
+
+	internals collectorInternals
+}
+
+// Start is used to start collecting messages with the session specified.
+func (m *MessageCollector) Start(s disgord.Session) error {
+	collected := make([]*disgord.Message, 0)
+	return m.internals.start(s, m.Clock, disgord.EvtMessageCreate, m.Max, m.Timeout, m.IdleTimeout, func(evt interface{}) bool {
+		msg := evt.(*disgord.MessageCreate).Message
+		return msg.ChannelID == m.ChannelID && (m.Filter == nil || m.Filter(msg))
+	}, func(evt interface{}) {
+		msg := evt.(*disgord.MessageCreate).Message
+		collected = append(collected, msg)
+		if m.OnCollect != nil {
+			m.OnCollect(msg)
+		}
+	}, func(reason CollectorEndReason) {
+		if m.OnEnd != nil {
+			m.OnEnd(collected, reason)
+		}
+	})
+}
+
+// Stop is used to stop collecting messages.
+func (m *MessageCollector) Stop() {
+	m.internals.stop()
+}
+
+// ReactionCollector is used to collect reactions added to a message. This can be used outside of commands.
+type ReactionCollector struct {
+	// MessageID is the ID of the message reactions are collected from.
+	MessageID disgord.Snowflake
+
+	// Filter is used to filter the reactions which are collected. This can be nil.
+	Filter func(evt *disgord.MessageReactionAdd) bool
+
+	// Max is the maximum number of reactions to collect. 0 means there is no limit.
+	Max int
+
+	// Timeout is the maximum time the collector will collect for. 0 means there is no timeout.
+	Timeout time.Duration
+
+	// IdleTimeout is the maximum time between reactions before the collector stops. 0 means there is no idle timeout.
+	IdleTimeout time.Duration
+
+	// Clock is used for the timeouts. nil will default to SystemClock.
+	Clock Clock
+
+	// OnCollect is called with each reaction collected. This can be nil.
+	OnCollect func(evt *disgord.MessageReactionAdd)
+
+	// OnEnd is called with all of the reactions collected and the reason when the collector stops. This can be nil.
+	OnEnd func(collected []*disgord.MessageReactionAdd, reason CollectorEndReason)
+
+	internals collectorInternals
+}
+
+// Start is used to start collecting reactions with the session specified.
+func (r *ReactionCollector) Start(s disgord.Session) error {
+	collected := make([]*disgord.MessageReactionAdd, 0)
+	return r.internals.start(s, r.Clock, disgord.EvtMessageReactionAdd, r.Max, r.Timeout, r.IdleTimeout, func(evt interface{}) bool {
+		e := evt.(*disgord.MessageReactionAdd)
+		return e.MessageID == r.MessageID && (r.Filter == nil || r.Filter(e))
+	}, func(evt interface{}) {
+		e := evt.(*disgord.MessageReactionAdd)
+		collected = append(collected, e)
+		if r.OnCollect != nil {
+			r.OnCollect(e)
+		}
+	}, func(reason CollectorEndReason) {
+		if r.OnEnd != nil {
+			r.OnEnd(collected, reason)
+		}
+	})
+}
+
+// Stop is used to stop collecting reactions.
+func (r *ReactionCollector) Stop() {
+	r.internals.stop()
+}
@@ -0,0 +1,69 @@
+package gommand
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// TestCollectors is used to test the message and reaction collectors.
+func TestCollectors(t *testing.T) {
+	s := newTestWaitSession()
+
+	// Test collecting messages until the limit.
+	ended := make(chan CollectorEndReason, 1)
+	var collected []*disgord.Message
+	m := &MessageCollector{
+		ChannelID: 1,
+		Filter: func(msg *disgord.Message) bool {
+			return msg.Content != "ignored"
+		},
+		Max: 2,
+		OnEnd: func(msgs []*disgord.Message, reason CollectorEndReason) {
+			collected = msgs
+			ended <- reason
+		},
+	}
+	if err := m.Start(s); err != nil {
+		t.Fatal(err)
+	}
+	s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{Message: &disgord.Message{ChannelID: 2, Content: "a"}})
+	s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{Message: &disgord.Message{ChannelID: 1, Content: "ignored"}})
+	s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{Message: &disgord.Message{ChannelID: 1, Content: "b"}})
+	s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{Message: &disgord.Message{ChannelID: 1, Content: "c"}})
+	if reason := <-ended; reason != CollectorLimitReached {
+		t.Fatal("wrong end reason:", reason)
+	}
+	if len(collected) != 2 || collected[0].Content != "b" || collected[1].Content != "c" {
+		t.Fatal("wrong messages collected")
+	}
+
+	// Test the idle timeout.
+	r := &ReactionCollector{
+		MessageID:   1,
+		IdleTimeout: time.Millisecond * 10,
+		OnEnd: func(_ []*disgord.MessageReactionAdd, reason CollectorEndReason) {
+			ended <- reason
+		},
+	}
+	if err := r.Start(s); err != nil {
+		t.Fatal(err)
+	}
+	if reason := <-ended; reason != CollectorIdle {
+		t.Fatal("wrong end reason:", reason)
+	}
+
+	// Test stopping the collector.
+	r.IdleTimeout = 0
+	if err := r.Start(s); err != nil {
+		t.Fatal(err)
+	}
+	r.Stop()
+	if reason := <-ended; reason != CollectorStopped {
+		t.Fatal("wrong end reason:", reason)
+	}
+	if s.waiting(disgord.EvtMessageReactionAdd) != 0 || s.waiting(disgord.EvtMessageCreate) != 0 {
+		t.Fatal("waiters were not removed")
+	}
+}
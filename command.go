@@ -2,7 +2,9 @@ package gommand
 
 import (
 	"io"
+	"runtime/debug"
 	"strings"
+	"time"
 )
 
 // ArgTransformer defines a transformer which is to be used on arguments.
@@ -48,17 +50,23 @@ type CommandInterface interface {
 // Command defines a command which can be used within the Router.
 type Command struct {
 	*commandBasics       `json:"-"`
-	Name                 string                   `json:"name"`
-	Aliases              []string                 `json:"aliases"`
-	Description          string                   `json:"description"`
-	Usage                string                   `json:"usage"`
-	Category             CategoryInterface        `json:"category"`
-	Cooldown             Cooldown                 `json:"cooldown"`
-	CommandAttributes    interface{}              `json:"commandAttributes"`
-	PermissionValidators []PermissionValidator    `json:"-"`
-	ArgTransformers      []ArgTransformer         `json:"-"`
-	Middleware           []Middleware             `json:"-"`
-	Function             func(ctx *Context) error `json:"-"`
+	Name                 string                          `json:"name"`
+	Aliases              []string                        `json:"aliases"`
+	Description          string                          `json:"description"`
+	Usage                string                          `json:"usage"`
+	Category             CategoryInterface               `json:"category"`
+	Cooldown             Cooldown                        `json:"cooldown"`
+	CommandAttributes    interface{}                     `json:"commandAttributes"`
+	PermissionValidators []PermissionValidator           `json:"-"`
+	ArgTransformers      []ArgTransformer                `json:"-"`
+	Middleware           []Middleware                    `json:"-"`
+	AroundMiddleware     []AroundMiddleware              `json:"-"`
+	AfterHooks           []AfterHook                     `json:"-"`
+	Hidden               bool                            `json:"hidden"`
+	Localisations        map[string]*CommandLocalisation `json:"localisations"`
+	ProducesText         bool                            `json:"producesText"`
+	ConsumesText         bool                            `json:"consumesText"`
+	Function             func(ctx *Context) error        `json:"-"`
 }
 
 // Init is used to initialise the command.
@@ -76,8 +84,8 @@ func (c *Command) CommandFunction(ctx *Context) error {
 func CommandHasPermission(ctx *Context, c CommandInterface) error {
 	// Run any permission validators on a global scale.
 	if ctx.Router.permissionValidators != nil {
-		for _, v := range ctx.Router.permissionValidators {
-			msg, ok := v(ctx)
+		for i, v := range ctx.Router.permissionValidators {
+			msg, ok := runPermissionValidator(ctx, "router", i, v)
 			if !ok {
 				return &IncorrectPermissions{err: msg}
 			}
@@ -86,8 +94,8 @@ func CommandHasPermission(ctx *Context, c CommandInterface) error {
 
 	// Run any permission validators on a category scale.
 	if c.GetCategory() != nil {
-		for _, v := range c.GetCategory().GetPermissionValidators() {
-			msg, ok := v(ctx)
+		for i, v := range c.GetCategory().GetPermissionValidators() {
+			msg, ok := runPermissionValidator(ctx, "category", i, v)
 			if !ok {
 				return &IncorrectPermissions{err: msg}
 			}
@@ -96,8 +104,8 @@ func CommandHasPermission(ctx *Context, c CommandInterface) error {
 
 	// Run any permission validators on a local scale.
 	if c.GetPermissionValidators() != nil {
-		for _, v := range c.GetPermissionValidators() {
-			msg, ok := v(ctx)
+		for i, v := range c.GetPermissionValidators() {
+			msg, ok := runPermissionValidator(ctx, "command", i, v)
 			if !ok {
 				return &IncorrectPermissions{err: msg}
 			}
@@ -116,33 +124,45 @@ func (c *Command) HasPermission(ctx *Context) error {
 
 // Used to run the command.
 func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err error) {
-	// Handle recovering from exceptions.
+	// Get the hooks.
+	hooks := ctx.Router.hooks()
+
+	// Handle recovering from exceptions outside of the command function.
 	defer func() {
 		if r := recover(); r != nil {
-			switch v := r.(type) {
-			case string:
-				ctx.Router.errorHandler(ctx, &PanicError{msg: v})
-			case error:
-				ctx.Router.errorHandler(ctx, v)
-			}
+			ctx.Router.errorHandler(ctx, newPanicError(r, debug.Stack()))
 		}
 	}()
 
+	// Resolve any sub-commands. The attributes sub-commands inherit from their groups are used for the checks below.
+	var inherited CommandInterface
+	c, inherited, reader = resolveSubcommands(ctx, reader, c)
+
+	// Check the command can be piped if it is part of a pipe.
+	if err = checkPipe(ctx, c); err != nil {
+		return
+	}
+
 	// Run any permission validators.
-	err = CommandHasPermission(ctx, c)
+	err = CommandHasPermission(ctx, inherited)
 	if err != nil {
+		if e, ok := err.(*IncorrectPermissions); ok {
+			hooks.PermissionDenied(ctx, c, e)
+		}
 		return
 	}
 
 	// Get the category.
-	cat := c.GetCategory()
+	cat := inherited.GetCategory()
 
 	// Check if the command is on cooldown.
-	cmdCooldown := c.GetCooldown()
+	cmdCooldown := inherited.GetCooldown()
 	if cmdCooldown != nil {
-		msg, ok := cmdCooldown.Check(ctx)
+		msg, ok := checkCooldown(ctx, "command", cmdCooldown)
 		if !ok {
-			return &CommandOnCooldown{Message: msg}
+			e := &CommandOnCooldown{Message: msg}
+			hooks.CooldownHit(ctx, c, e)
+			return e
 		}
 	}
 	routerCooldown := ctx.Router.Cooldown
@@ -151,38 +171,59 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 		catCooldown = cat.GetCooldown()
 	}
 	if catCooldown != nil && cmdCooldown != catCooldown && routerCooldown != catCooldown {
-		msg, ok := catCooldown.Check(ctx)
+		msg, ok := checkCooldown(ctx, "category", catCooldown)
 		if !ok {
-			return &CommandOnCooldown{Message: msg}
+			e := &CommandOnCooldown{Message: msg}
+			hooks.CooldownHit(ctx, c, e)
+			return e
 		}
 	}
 	if routerCooldown != nil && cmdCooldown != routerCooldown {
-		msg, ok := routerCooldown.Check(ctx)
+		msg, ok := checkCooldown(ctx, "router", routerCooldown)
 		if !ok {
-			return &CommandOnCooldown{Message: msg}
+			e := &CommandOnCooldown{Message: msg}
+			hooks.CooldownHit(ctx, c, e)
+			return e
 		}
 	}
 
+	// If the middleware or arguments stop the command from running, it is still counted as finished with the error.
+	started := ctx.Router.clock().Now()
+	functionRan := false
+	defer func() {
+		if err != nil && !functionRan {
+			ctx.Router.commandFinished(ctx, hooks, c, ctx.Router.clock().Now().Sub(started), err)
+		}
+	}()
+
 	// Run any middleware.
 	if ctx.Router.middleware != nil {
-		for _, v := range ctx.Router.middleware {
-			err = v(ctx)
+		for i, v := range ctx.Router.middleware {
+			err = runMiddleware(ctx, "router", i, v)
 			if err != nil {
 				return
 			}
 		}
 	}
 	if cat != nil {
-		for _, v := range cat.GetMiddleware() {
-			err = v(ctx)
+		for i, v := range cat.GetMiddleware() {
+			err = runMiddleware(ctx, "category", i, v)
+			if err != nil {
+				return
+			}
+		}
+	}
+	for _, g := range ctx.Groups {
+		for i, v := range g.Middleware {
+			err = runMiddleware(ctx, "group", i, v)
 			if err != nil {
 				return
 			}
 		}
 	}
 	if c.GetMiddleware() != nil {
-		for _, v := range c.GetMiddleware() {
-			err = v(ctx)
+		for i, v := range c.GetMiddleware() {
+			err = runMiddleware(ctx, "command", i, v)
 			if err != nil {
 				return
 			}
@@ -220,10 +261,10 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 					// Is this an optional argument?
 					if !v.Optional {
 						parser.Done()
-						return &IncorrectPermissions{err: "Remainder cannot be optional."}
+						return &InvalidArgCount{err: ctx.Translate(MessageArgumentMissing)}
 					}
 				} else {
-					x, err := v.Function(ctx, remainder)
+					x, err := transformArg(ctx, i, v, remainder)
 					if err != nil {
 						parser.Done()
 						return err
@@ -250,7 +291,7 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 								break
 							} else {
 								// This isn't optional and no default was provided - throw an error.
-								err = &InvalidArgCount{err: "Expected an argument for the greedy converter."}
+								err = &InvalidArgCount{err: ctx.Translate(MessageGreedyArgumentMissing)}
 								parser.Done()
 								return
 							}
@@ -259,7 +300,7 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 						}
 					} else {
 						// Attempt to parse this argument.
-						res, err := v.Function(ctx, Argument.Text)
+						res, err := transformArg(ctx, i, v, Argument.Text)
 						if err != nil {
 							if FirstArg {
 								parser.Done()
@@ -280,7 +321,7 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 				// Try and get one argument.
 				Argument := parser.GetNextArg()
 				if Argument != nil {
-					x, err := v.Function(ctx, Argument.Text)
+					x, err := transformArg(ctx, i, v, Argument.Text)
 					if err != nil {
 						parser.Done()
 						return err
@@ -296,7 +337,7 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 					break
 				} else {
 					parser.Done()
-					return &InvalidArgCount{err: "A required argument is missing."}
+					return &InvalidArgCount{err: ctx.Translate(MessageArgumentMissing)}
 				}
 			}
 		}
@@ -307,8 +348,81 @@ func runCommand(ctx *Context, reader io.ReadSeeker, c CommandInterface) (err err
 		// Set the arguments.
 		ctx.Args = Args
 	}
+	hooks.ArgsParsed(ctx, c)
+
+	// Run the command within the around middleware, then run the after hooks and return.
+	err = runWrapped(ctx, cat, c, func() error {
+		functionRan = true
+		return runCommandFunction(ctx, hooks, c)
+	})
+	return
+}
+
+// Runs the command function within its own span. If the command panics, this returns a PanicError.
+func runCommandFunction(ctx *Context, hooks Hooks, c CommandInterface) (err error) {
+	hooks.CommandStarted(ctx, c)
+	span := ctx.StartSpan("command")
+	span.SetAttribute("command", c.GetName())
+	parent := ctx.span
+	ctx.span = span
+	started := ctx.Router.clock().Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r, debug.Stack())
+		}
+		ctx.span = parent
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		ctx.Router.commandFinished(ctx, hooks, c, ctx.Router.clock().Now().Sub(started), err)
+	}()
+	return c.CommandFunction(ctx)
+}
+
+// Runs the hooks, logging and usage recording for a finished command.
+func (r *Router) commandFinished(ctx *Context, hooks Hooks, c CommandInterface, duration time.Duration, err error) {
+	hooks.CommandFinished(ctx, c, duration, err)
+	r.logCommandFinished(ctx, c, duration, err)
+	r.recordUsage(ctx, err)
+}
+
+// Runs a permission validator within a span.
+func runPermissionValidator(ctx *Context, scope string, index int, v PermissionValidator) (string, bool) {
+	span := ctx.StartSpan("permission_validator")
+	span.SetAttribute("scope", scope)
+	span.SetAttribute("index", index)
+	msg, ok := v(ctx)
+	span.SetAttribute("allowed", ok)
+	span.End()
+	return msg, ok
+}
+
+// Checks a cooldown within a span.
+func checkCooldown(ctx *Context, scope string, cooldown Cooldown) (string, bool) {
+	span := ctx.StartSpan("cooldown")
+	span.SetAttribute("scope", scope)
+	msg, ok := cooldown.Check(ctx)
+	span.SetAttribute("allowed", ok)
+	span.End()
+	return msg, ok
+}
+
+// Runs a middleware function within a span.
+func runMiddleware(ctx *Context, scope string, index int, v Middleware) error {
+	return ctx.traceFunc("middleware", func(span Span) error {
+		span.SetAttribute("scope", scope)
+		span.SetAttribute("index", index)
+		return v(ctx)
+	})
+}
 
-	// Run the command and return.
-	err = c.CommandFunction(ctx)
+// Runs an argument transformer within a span.
+func transformArg(ctx *Context, index int, v ArgTransformer, arg string) (res interface{}, err error) {
+	err = ctx.traceFunc("arg_transformer", func(span Span) error {
+		span.SetAttribute("index", index)
+		res, err = v.Function(ctx, arg)
+		return err
+	})
 	return
 }
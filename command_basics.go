@@ -1,15 +1,21 @@
 package gommand
 
 type commandBasics struct {
-	Name                 string                `json:"name"`
-	Aliases              []string              `json:"aliases"`
-	Description          string                `json:"description"`
-	Usage                string                `json:"usage"`
-	Category             CategoryInterface     `json:"category"`
-	Cooldown             Cooldown              `json:"cooldown"`
-	PermissionValidators []PermissionValidator `json:"-"`
-	ArgTransformers      []ArgTransformer      `json:"-"`
-	Middleware           []Middleware          `json:"-"`
+	Name                 string                          `json:"name"`
+	Aliases              []string                        `json:"aliases"`
+	Description          string                          `json:"description"`
+	Usage                string                          `json:"usage"`
+	Category             CategoryInterface               `json:"category"`
+	Cooldown             Cooldown                        `json:"cooldown"`
+	PermissionValidators []PermissionValidator           `json:"-"`
+	ArgTransformers      []ArgTransformer                `json:"-"`
+	Middleware           []Middleware                    `json:"-"`
+	AroundMiddleware     []AroundMiddleware              `json:"-"`
+	AfterHooks           []AfterHook                     `json:"-"`
+	Hidden               bool                            `json:"hidden"`
+	Localisations        map[string]*CommandLocalisation `json:"localisations"`
+	ProducesText         bool                            `json:"producesText"`
+	ConsumesText         bool                            `json:"consumesText"`
 	parent               *Command
 }
 
@@ -102,3 +108,57 @@ func (obj *commandBasics) GetMiddleware() []Middleware {
 		return obj.parent.Middleware
 	}
 }
+
+// GetAroundMiddleware is used to get the around middleware.
+func (obj *commandBasics) GetAroundMiddleware() []AroundMiddleware {
+	if obj.parent == nil {
+		return obj.AroundMiddleware
+	} else {
+		return obj.parent.AroundMiddleware
+	}
+}
+
+// GetAfterHooks is used to get the after hooks.
+func (obj *commandBasics) GetAfterHooks() []AfterHook {
+	if obj.parent == nil {
+		return obj.AfterHooks
+	} else {
+		return obj.parent.AfterHooks
+	}
+}
+
+// GetHidden is used to get if the command is hidden.
+func (obj *commandBasics) GetHidden() bool {
+	if obj.parent == nil {
+		return obj.Hidden
+	} else {
+		return obj.parent.Hidden
+	}
+}
+
+// GetLocalisations is used to get the names, aliases and descriptions of the command in other locales.
+func (obj *commandBasics) GetLocalisations() map[string]*CommandLocalisation {
+	if obj.parent == nil {
+		return obj.Localisations
+	} else {
+		return obj.parent.Localisations
+	}
+}
+
+// GetProducesText is used to get if the text the command replies with can be piped into another command.
+func (obj *commandBasics) GetProducesText() bool {
+	if obj.parent == nil {
+		return obj.ProducesText
+	} else {
+		return obj.parent.ProducesText
+	}
+}
+
+// GetConsumesText is used to get if the command can be given the text from another command with ctx.Input.
+func (obj *commandBasics) GetConsumesText() bool {
+	if obj.parent == nil {
+		return obj.ConsumesText
+	} else {
+		return obj.parent.ConsumesText
+	}
+}
@@ -0,0 +1,123 @@
+package gommand
+
+import "strings"
+
+// CommandLocalisation is used to define the name, aliases and description of a command in a locale.
+// Any fields which are blank will fall back to the ones set on the command.
+type CommandLocalisation struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	Description string   `json:"description"`
+}
+
+// LocalisationsProvider is an optional interface which commands can implement to have their names, aliases and descriptions in other locales.
+// The map is keyed by the locale (such as "fr" or "pt-BR"), matching the locales returned by the LocaleResolver.
+type LocalisationsProvider interface {
+	GetLocalisations() map[string]*CommandLocalisation
+}
+
+// Gets the language of the locale (such as "pt" for "pt-BR"). This is a blank string if the locale does not have a region.
+func localeLanguage(Locale string) string {
+	if i := strings.IndexAny(Locale, "-_"); i != -1 {
+		return Locale[:i]
+	}
+	return ""
+}
+
+// Gets the localisations of the command if it has any.
+func commandLocalisations(cmd CommandInterface) map[string]*CommandLocalisation {
+	if c, ok := cmd.(*inheritedCommand); ok {
+		cmd = c.CommandInterface
+	}
+	p, ok := cmd.(LocalisationsProvider)
+	if !ok {
+		return nil
+	}
+	return p.GetLocalisations()
+}
+
+// GetLocalisation is used to get the localisation of the command in the locale, falling back to the language of the locale.
+// If the command is not localised in the locale, this will be nil.
+func GetLocalisation(Command CommandInterface, Locale string) *CommandLocalisation {
+	if Locale == "" {
+		return nil
+	}
+	l := commandLocalisations(Command)
+	if l == nil {
+		return nil
+	}
+	if x := l[Locale]; x != nil {
+		return x
+	}
+	if lang := localeLanguage(Locale); lang != "" {
+		return l[lang]
+	}
+	return nil
+}
+
+// LocalisedName is used to get the name of the command in the locale. If it is not localised, the name of the command is used.
+func LocalisedName(Command CommandInterface, Locale string) string {
+	if l := GetLocalisation(Command, Locale); l != nil && l.Name != "" {
+		return l.Name
+	}
+	return Command.GetName()
+}
+
+// LocalisedAliases is used to get the aliases of the command in the locale. If they are not localised, the aliases of the command are used.
+func LocalisedAliases(Command CommandInterface, Locale string) []string {
+	if l := GetLocalisation(Command, Locale); l != nil && l.Aliases != nil {
+		return l.Aliases
+	}
+	return Command.GetAliases()
+}
+
+// LocalisedDescription is used to get the description of the command in the locale. If it is not localised, the description of the command is used.
+func LocalisedDescription(Command CommandInterface, Locale string) string {
+	if l := GetLocalisation(Command, Locale); l != nil && l.Description != "" {
+		return l.Description
+	}
+	return Command.GetDescription()
+}
+
+// LocalisedUsage is used to get the usage of the command in the locale. For command groups, this lists the sub-commands by their names in the locale.
+func LocalisedUsage(Command CommandInterface, Locale string) string {
+	if g, ok := Command.(*CommandGroup); ok {
+		return g.GetLocalisedUsage(Locale)
+	}
+	return Command.GetUsage()
+}
+
+// Adds the localised names and aliases of the command to the map of locales. The commands lock must be held.
+func addLocalisedCommand(m map[string]map[string]CommandInterface, c CommandInterface) {
+	for locale, l := range commandLocalisations(c) {
+		if l == nil {
+			continue
+		}
+		names := m[locale]
+		if names == nil {
+			names = map[string]CommandInterface{}
+			m[locale] = names
+		}
+		if l.Name != "" {
+			names[strings.ToLower(l.Name)] = c
+		}
+		for _, v := range l.Aliases {
+			names[strings.ToLower(v)] = c
+		}
+	}
+}
+
+// Gets a command from the map of locales, trying the locale and then the language of the locale.
+func getLocalisedCommand(m map[string]map[string]CommandInterface, Locale, Name string) CommandInterface {
+	if Locale == "" {
+		return nil
+	}
+	Name = strings.ToLower(Name)
+	if cmd := m[Locale][Name]; cmd != nil {
+		return cmd
+	}
+	if lang := localeLanguage(Locale); lang != "" {
+		return m[lang][Name]
+	}
+	return nil
+}
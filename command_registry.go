@@ -0,0 +1,57 @@
+package gommand
+
+import (
+	"errors"
+	"sort"
+)
+
+// CommandFactory is used to create a command. This is called every time the commands are loaded, meaning a new command is created on each reload.
+type CommandFactory = func() (CommandInterface, error)
+
+// CommandRegistry is used to map names to the factories which create the commands.
+// This allows the commands to be recreated when they are reloaded.
+type CommandRegistry map[string]CommandFactory
+
+// Register is used to add a factory to the registry.
+func (c CommandRegistry) Register(Name string, Factory CommandFactory) {
+	c[Name] = Factory
+}
+
+// Build is used to create the commands from the factories, ordered by the names of the factories.
+// If Names is empty, every factory is used. Otherwise, only the factories with the names given are used.
+func (c CommandRegistry) Build(Names ...string) ([]CommandInterface, error) {
+	if len(Names) == 0 {
+		Names = make([]string, 0, len(c))
+		for k := range c {
+			Names = append(Names, k)
+		}
+		sort.Strings(Names)
+	}
+	cmds := make([]CommandInterface, len(Names))
+	for i, name := range Names {
+		factory, ok := c[name]
+		if !ok {
+			return nil, errors.New("the command factory \"" + name + "\" is not registered")
+		}
+		cmd, err := factory()
+		if err != nil {
+			return nil, err
+		}
+		if cmd == nil {
+			return nil, errors.New("the command factory \"" + name + "\" returned a nil command")
+		}
+		cmds[i] = cmd
+	}
+	return cmds, nil
+}
+
+// LoadCommands is used to build the commands from the registry and replace all of the commands in the Router with them.
+// If any of the factories fail or any of the commands conflict, the error is returned and the commands are not changed.
+// This can be used within the ReloadCommands function of the developer commands to hot-reload the commands.
+func (r *Router) LoadCommands(Registry CommandRegistry, Names ...string) error {
+	cmds, err := Registry.Build(Names...)
+	if err != nil {
+		return err
+	}
+	return r.ReplaceCommands(cmds)
+}
@@ -0,0 +1,167 @@
+package gommand
+
+import "strings"
+
+// Defines the commands and their names which the router uses. This is not modified once it is used by the router.
+// Instead, a copy is made with the changes and then swapped in, meaning the commands are always consistent to anything reading them.
+type commandTable struct {
+	// Maps the lowercase names and aliases to the commands.
+	cmds map[string]CommandInterface
+
+	// Maps the locales to the lowercase localised names and aliases.
+	localised map[string]map[string]CommandInterface
+}
+
+// Creates an empty command table.
+func newCommandTable() *commandTable {
+	return &commandTable{
+		cmds:      map[string]CommandInterface{},
+		localised: map[string]map[string]CommandInterface{},
+	}
+}
+
+// Creates a copy of the command table which can be modified.
+func (t *commandTable) clone() *commandTable {
+	n := &commandTable{
+		cmds:      make(map[string]CommandInterface, len(t.cmds)),
+		localised: make(map[string]map[string]CommandInterface, len(t.localised)),
+	}
+	for k, v := range t.cmds {
+		n.cmds[k] = v
+	}
+	for locale, names := range t.localised {
+		m := make(map[string]CommandInterface, len(names))
+		for k, v := range names {
+			m[k] = v
+		}
+		n.localised[locale] = m
+	}
+	return n
+}
+
+// Gets the lowercase names and aliases of the command, and the lowercase localised names and aliases for each locale.
+func commandKeys(c CommandInterface) ([]string, map[string][]string) {
+	keys := []string{strings.ToLower(c.GetName())}
+	for _, v := range c.GetAliases() {
+		keys = append(keys, strings.ToLower(v))
+	}
+	localised := map[string][]string{}
+	for locale, l := range commandLocalisations(c) {
+		if l == nil {
+			continue
+		}
+		if l.Name != "" {
+			localised[locale] = append(localised[locale], strings.ToLower(l.Name))
+		}
+		for _, v := range l.Aliases {
+			localised[locale] = append(localised[locale], strings.ToLower(v))
+		}
+	}
+	return keys, localised
+}
+
+// Gets the command with the same name as this command if it exists.
+func (t *commandTable) sameName(c CommandInterface) CommandInterface {
+	existing := t.cmds[strings.ToLower(c.GetName())]
+	if existing != nil && strings.EqualFold(existing.GetName(), c.GetName()) {
+		return existing
+	}
+	return nil
+}
+
+// Checks if the names of the command conflict with a different command. A command with the same name is not a conflict since it will be replaced.
+func (t *commandTable) conflict(c CommandInterface) *CommandConflict {
+	replaced := t.sameName(c)
+	keys, localised := commandKeys(c)
+	for _, k := range keys {
+		if existing := t.cmds[k]; existing != nil && existing != replaced {
+			return &CommandConflict{Name: k, Command: c, Existing: existing}
+		}
+	}
+	for locale, names := range localised {
+		for _, k := range names {
+			if existing := t.localised[locale][k]; existing != nil && existing != replaced {
+				return &CommandConflict{Name: k, Locale: locale, Command: c, Existing: existing}
+			}
+		}
+	}
+	return nil
+}
+
+// Adds the command to the table, replacing any command with the same name. This returns a *CommandConflict if a name or alias is used by a different command.
+func (t *commandTable) add(c CommandInterface) error {
+	if err := t.conflict(c); err != nil {
+		return err
+	}
+	if replaced := t.sameName(c); replaced != nil {
+		t.remove(replaced)
+	}
+	keys, localised := commandKeys(c)
+	for _, k := range keys {
+		t.cmds[k] = c
+	}
+	for locale, names := range localised {
+		m := t.localised[locale]
+		if m == nil {
+			m = map[string]CommandInterface{}
+			t.localised[locale] = m
+		}
+		for _, k := range names {
+			m[k] = c
+		}
+	}
+	return nil
+}
+
+// Removes the command from the table. Only names and aliases which belong to the command with the same name are removed.
+func (t *commandTable) remove(c CommandInterface) {
+	existing := t.sameName(c)
+	if existing == nil {
+		return
+	}
+	for k, v := range t.cmds {
+		if v == existing {
+			delete(t.cmds, k)
+		}
+	}
+	for locale, names := range t.localised {
+		for k, v := range names {
+			if v == existing {
+				delete(names, k)
+			}
+		}
+		if len(names) == 0 {
+			delete(t.localised, locale)
+		}
+	}
+}
+
+// Gets a command by its name or its name in any locale.
+func (t *commandTable) getAnyLocale(Name string) CommandInterface {
+	Name = strings.ToLower(Name)
+	if cmd := t.cmds[Name]; cmd != nil {
+		return cmd
+	}
+	for _, v := range t.localised {
+		if cmd := v[Name]; cmd != nil {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Gets a command by its name in the locale or its name.
+func (t *commandTable) get(Locale, Name string) CommandInterface {
+	if Locale != "" {
+		Name = strings.ToLower(Name)
+		if cmd := t.localised[Locale][Name]; cmd != nil {
+			return cmd
+		}
+		if lang := localeLanguage(Locale); lang != "" {
+			if cmd := t.localised[lang][Name]; cmd != nil {
+				return cmd
+			}
+		}
+	}
+	return t.cmds[strings.ToLower(Name)]
+}
@@ -0,0 +1,226 @@
+package gommand
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Creates a command which does nothing.
+func noopCommand(Name string, Aliases ...string) *Command {
+	return &Command{
+		Name:    Name,
+		Aliases: Aliases,
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	}
+}
+
+// TestCommandConflicts is used to test setting and removing commands with names and aliases which are used by other commands.
+func TestCommandConflicts(t *testing.T) {
+	r := NewRouter(&RouterConfig{})
+	a := noopCommand("a", "x", "y")
+	if err := r.TrySetCommand(a); err != nil {
+		t.Fatal(err)
+	}
+
+	// A different command using an alias should conflict and not change anything.
+	err := r.TrySetCommand(noopCommand("b", "x"))
+	conflict, ok := err.(*CommandConflict)
+	if !ok || conflict.Name != "x" || conflict.Existing != a {
+		t.Fatal("expected a conflict:", err)
+	}
+	if r.GetCommand("b") != nil || r.GetCommand("x") != a {
+		t.Fatal("the commands should not change when there is a conflict")
+	}
+	err = r.TrySetCommand(&Command{Name: "c", Localisations: map[string]*CommandLocalisation{"fr": {Name: "z"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.TrySetCommand(&Command{Name: "d", Localisations: map[string]*CommandLocalisation{"fr": {Aliases: []string{"Z"}}}}); err == nil || err.(*CommandConflict).Locale != "fr" {
+		t.Fatal("expected a localised conflict:", err)
+	}
+
+	// A command with the same name should replace the command, including the aliases which it no longer has.
+	a2 := noopCommand("A", "y")
+	if err = r.TrySetCommand(a2); err != nil {
+		t.Fatal(err)
+	}
+	if r.GetCommand("a") != a2 || r.GetCommand("y") != a2 || r.GetCommand("x") != nil {
+		t.Fatal("the command was not replaced")
+	}
+
+	// Removing the old command should not remove aliases which belong to a newer command.
+	b := noopCommand("b", "x")
+	if err = r.TrySetCommand(b); err != nil {
+		t.Fatal(err)
+	}
+	r.RemoveCommand(a)
+	if r.GetCommand("a") != nil || r.GetCommand("y") != nil || r.GetCommand("x") != b {
+		t.Fatal("unexpected commands after removing")
+	}
+}
+
+// A cooldown which counts the times it is initialised.
+type countingCooldown struct {
+	inits int
+}
+
+func (c *countingCooldown) Init() {
+	c.inits++
+}
+
+func (c *countingCooldown) Check(*Context) (string, bool) {
+	return "", true
+}
+
+func (c *countingCooldown) Clear() {}
+
+// TestSetCommandFailure is used to test that a command which fails to be set is logged and does not have its cooldown initialised.
+func TestSetCommandFailure(t *testing.T) {
+	logger := &recordingLogger{}
+	r := NewRouter(&RouterConfig{Logger: logger})
+	a := noopCommand("a")
+	r.SetCommand(a)
+	cooldown := &countingCooldown{}
+	b := noopCommand("b", "a")
+	b.Cooldown = cooldown
+	r.SetCommand(b)
+	if logger.lines[len(logger.lines)-1] != "error Failed to set the command." || logger.last["command"] != "b" {
+		t.Fatal("the conflict was not logged:", logger.lines)
+	}
+	if cooldown.inits != 0 {
+		t.Fatal("the cooldown should not be initialised when setting the command fails")
+	}
+	if err := r.ReplaceCommands([]CommandInterface{noopCommand("a"), b}); err == nil || cooldown.inits != 0 {
+		t.Fatal("the cooldown should not be initialised when replacing the commands fails:", err)
+	}
+	r.RemoveCommand(a)
+	r.SetCommand(b)
+	if cooldown.inits != 1 {
+		t.Fatal("the cooldown should be initialised once the command is set")
+	}
+}
+
+// TestReplaceCommands is used to test replacing the commands and loading them from a registry.
+func TestReplaceCommands(t *testing.T) {
+	r := NewRouter(&RouterConfig{Developer: &DeveloperConfig{}})
+	old := noopCommand("old", "o")
+	if err := r.TrySetCommand(old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Look up the commands while they are being replaced. The alias and name should always be the same command.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			r.cmdLock.RLock()
+			t1, t2 := r.cmds.get("", "new"), r.cmds.get("", "n")
+			r.cmdLock.RUnlock()
+			if t1 != t2 {
+				t.Error("the alias and name point to different commands")
+				return
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if err := r.ReplaceCommands([]CommandInterface{noopCommand("new", "n")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	if r.GetCommand("old") != nil || r.GetCommand("o") != nil || r.GetCommand("help") == nil || r.GetCommand("router-stats") == nil {
+		t.Fatal("the built-in commands should be kept and the old commands removed")
+	}
+
+	// Check a conflict doesn't change the commands.
+	current := r.GetCommand("new")
+	if err := r.ReplaceCommands([]CommandInterface{noopCommand("a", "x"), noopCommand("b", "x")}); err == nil {
+		t.Fatal("expected a conflict")
+	}
+	if r.GetCommand("new") != current || r.GetCommand("a") != nil {
+		t.Fatal("the commands should not change when there is a conflict")
+	}
+
+	// Load the commands from a registry.
+	registry := CommandRegistry{}
+	registry.Register("ping", func() (CommandInterface, error) {
+		return noopCommand("ping"), nil
+	})
+	registry.Register("pong", func() (CommandInterface, error) {
+		return noopCommand("pong"), nil
+	})
+	if err := r.LoadCommands(registry, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if r.GetCommand("ping") == nil || r.GetCommand("pong") != nil || r.GetCommand("new") != nil {
+		t.Fatal("only the ping command should be loaded")
+	}
+	first := r.GetCommand("ping")
+	if err := r.LoadCommands(registry); err != nil {
+		t.Fatal(err)
+	}
+	if r.GetCommand("ping") == first || r.GetCommand("pong") == nil {
+		t.Fatal("the commands should be recreated")
+	}
+	registry.Register("broken", func() (CommandInterface, error) {
+		return nil, errors.New("broken")
+	})
+	if err := r.LoadCommands(registry); err == nil || err.Error() != "broken" || r.GetCommand("ping") == nil {
+		t.Fatal("the error should be returned without changing the commands:", err)
+	}
+	if err := r.LoadCommands(registry, "missing"); err == nil {
+		t.Fatal("expected an error for a missing factory")
+	}
+}
+
+// Used as a shortcut storage adapter which sets a command when it is used.
+type commandSettingShortcutStorageAdapter struct {
+	InMemoryShortcutStorageAdapter
+	r *Router
+}
+
+func (s *commandSettingShortcutStorageAdapter) Get(GuildID disgord.Snowflake, Name string) (*Shortcut, error) {
+	s.r.SetCommand(noopCommand("set-by-adapter"))
+	return s.InMemoryShortcutStorageAdapter.Get(GuildID, Name)
+}
+
+// TestCommandLockNotHeld is used to test that the command lock is not held while storage adapters are used.
+func TestCommandLockNotHeld(t *testing.T) {
+	adapter := &commandSettingShortcutStorageAdapter{}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Shortcuts:   &ShortcutsConfig{StorageAdapter: adapter, DisableCommands: true},
+	})
+	adapter.r = r
+	r.AddErrorHandler(func(*Context, error) bool {
+		return true
+	})
+	done := make(chan struct{})
+	go func() {
+		// The adapter is only used for names which are not commands.
+		r.CommandProcessor(nil, 0, mockMessage("%missing"), true)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the command lock was held while the shortcut storage adapter was used")
+	}
+	if r.GetCommand("set-by-adapter") == nil {
+		t.Fatal("the command should have been set")
+	}
+}
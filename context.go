@@ -21,12 +21,67 @@ type Context struct {
 	WaitManager      *WaitManager           `json:"-"`
 	MiddlewareParams map[string]interface{} `json:"middlewareParams"`
 	State            interface{}            `json:"state"`
+
+	// Groups is the path of command groups which were invoked to get to the command, from the outermost group to the innermost one.
+	Groups []*CommandGroup `json:"groups"`
+
+	// Shortcut is the guild shortcut which was used to run the command. This is nil if a shortcut was not used.
+	Shortcut *Shortcut `json:"shortcut"`
+
+	// Input is the text which was piped into the command from the previous command in a chain (such as "!search foo | !translate fr"). This is blank if nothing was piped.
+	Input string `json:"input"`
+
+	// Defines if the output of the previous command in the chain was piped into this one.
+	piped bool
+
+	// The replies which were captured to be piped into the next command. If this is nil, replies are sent.
+	capture *[]string
+
+	// The span which new spans are started within.
+	span Span
+
+	// The cached locale of the command invocation.
+	locale         string
+	localeResolved bool
+}
+
+// CommandPath is used to get the full path of the command which was invoked, including any command groups (for example, "group sub").
+func (c *Context) CommandPath() string {
+	names := make([]string, 0, len(c.Groups)+1)
+	for _, v := range c.Groups {
+		names = append(names, v.GetName())
+	}
+	if c.Command != nil && c.Command.GetName() != "" {
+		names = append(names, c.Command.GetName())
+	}
+	return strings.Join(names, " ")
+}
+
+// Category is used to get the category of the command which was invoked.
+// Unlike ctx.Command.GetCategory(), this includes the category a sub-command inherits from its groups.
+func (c *Context) Category() CategoryInterface {
+	if c.Command != nil {
+		if cat := c.Command.GetCategory(); cat != nil {
+			return cat
+		}
+	}
+	for i := len(c.Groups) - 1; i >= 0; i-- {
+		if cat := c.Groups[i].GetCategory(); cat != nil {
+			return cat
+		}
+	}
+	return nil
 }
 
 // Replay is used to replay a command.
 func (c *Context) Replay() error {
 	c.Args = []interface{}{}
-	return runCommand(c, strings.NewReader(c.RawArgs), c.Command)
+	cmd := c.Command
+	if len(c.Groups) != 0 {
+		cmd = c.Groups[0]
+		c.Groups = nil
+	}
+	return runCommand(c, strings.NewReader(c.RawArgs), cmd)
 }
 
 // BotMember is used to get the bot as a member of the server this was within.
@@ -45,10 +100,57 @@ func (c *Context) Channel() (*disgord.Channel, error) {
 }
 
 // Reply is used to quickly reply to a command with a message.
+// If the output of the command is being piped into another command, the text of the reply is captured rather than being sent, and the message returned is not a real message (its ID is 0).
 func (c *Context) Reply(data ...interface{}) (*disgord.Message, error) {
+	if c.capture != nil {
+		text := capturedText(data)
+		*c.capture = append(*c.capture, text)
+		return &disgord.Message{ChannelID: c.Message.ChannelID, GuildID: c.Message.GuildID, Author: c.BotUser, Content: text}, nil
+	}
 	return c.Session.SendMsg(c.Message.ChannelID, data...)
 }
 
+// Capturing is used to check if the replies of the command are being captured to be piped into another command.
+func (c *Context) Capturing() bool {
+	return c.capture != nil
+}
+
+// Gets the text of the data given to Reply. Embeds are turned into their title and description.
+func capturedText(data []interface{}) string {
+	lines := []string{}
+	addEmbed := func(e *disgord.Embed) {
+		if e == nil {
+			return
+		}
+		for _, v := range []string{e.Title, e.Description} {
+			if v != "" {
+				lines = append(lines, v)
+			}
+		}
+	}
+	for _, v := range data {
+		switch x := v.(type) {
+		case string:
+			lines = append(lines, x)
+		case *disgord.CreateMessageParams:
+			if x.Content != "" {
+				lines = append(lines, x.Content)
+			}
+			addEmbed(x.Embed)
+		case disgord.CreateMessageParams:
+			if x.Content != "" {
+				lines = append(lines, x.Content)
+			}
+			addEmbed(x.Embed)
+		case *disgord.Embed:
+			addEmbed(x)
+		case disgord.Embed:
+			addEmbed(&x)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // EmbedTextFailover is used to check the permissions when sending a message and failover to sending text if we cannot send an embed but can send a message.
 // If the bot doesn't have permissions to send a message, we return an error.
 // If the bot has permission to send an embed, we use the embed generator, and if we don't we use the text generator.
@@ -162,7 +264,12 @@ func (c *Context) DisplayEmbedMenu(m *EmbedMenu) error {
 
 // DisplayEmbedMenuWithLifetime is used to easily display an embed menu with a lifetime.
 func (c *Context) DisplayEmbedMenuWithLifetime(m *EmbedMenu, lifetime *EmbedLifetimeOptions) error {
-	msg, err := c.Reply("Loading...")
+	if c.capture != nil {
+		// There is no message to add the reactions to, so just capture the text of the embed.
+		_, err := c.Reply(m.Embed)
+		return err
+	}
+	msg, err := c.Reply(c.Translate(MessageMenuLoading))
 	if err != nil {
 		return err
 	}
@@ -171,6 +278,8 @@ func (c *Context) DisplayEmbedMenuWithLifetime(m *EmbedMenu, lifetime *EmbedLife
 		return err
 	}
 	if lifetime != nil {
+		lifetime.clock = c.Router.clock()
+		lifetime.router = c.Router
 		lifetime.Start(msg.ChannelID, msg.ID, c.Session)
 	}
 	return nil
@@ -2,7 +2,6 @@ package gommand
 
 import (
 	"github.com/andersfylling/disgord"
-	"github.com/hako/durafmt"
 	"sync"
 	"time"
 )
@@ -46,7 +45,10 @@ func (i *cooldownInternals) expire(id disgord.Snowflake) {
 }
 
 // Used to check a usage.
-func (i *cooldownInternals) check(id disgord.Snowflake, max uint, expires time.Duration) (message string, shouldRun bool) {
+func (i *cooldownInternals) check(ctx *Context, id disgord.Snowflake, max uint, expires time.Duration) (message string, shouldRun bool) {
+	// Resolve the locale before locking since the resolver might be slow.
+	ctx.Locale()
+
 	// Lock the mutex until we're done.
 	i.coolingDownLock.Lock()
 	defer i.coolingDownLock.Unlock()
@@ -56,8 +58,7 @@ func (i *cooldownInternals) check(id disgord.Snowflake, max uint, expires time.D
 
 	// Is the usages equal to max runs? If so, return false.
 	if usages == max {
-		durationFmt := durafmt.Parse(expires).String()
-		return "This command has a " + durationFmt + " cooldown.", false
+		return ctx.Translate(MessageCooldown, "duration", ctx.FormatDuration(expires, 0)), false
 	}
 
 	// Add 1 to usages and set it.
@@ -65,7 +66,7 @@ func (i *cooldownInternals) check(id disgord.Snowflake, max uint, expires time.D
 	i.coolingDown[id] = usages
 
 	// Expire this usage.
-	time.AfterFunc(expires, func() {
+	ctx.Router.clock().AfterFunc(expires, func() {
 		i.expire(id)
 	})
 
@@ -99,7 +100,7 @@ func (g *GuildCooldown) Init() {
 
 // Check is used to check if the command should run and add 1 to the guild count.
 func (g *GuildCooldown) Check(ctx *Context) (string, bool) {
-	return g.internals.check(ctx.Message.GuildID, g.MaxRuns, g.UsageExpires)
+	return g.internals.check(ctx, ctx.Message.GuildID, g.MaxRuns, g.UsageExpires)
 }
 
 // Clear is used to clear all cooldowns.
@@ -140,7 +141,7 @@ func (u *UserCooldown) Init() {
 
 // Check is used to check if the command should run and add 1 to the user count.
 func (u *UserCooldown) Check(ctx *Context) (string, bool) {
-	return u.internals.check(ctx.Message.Author.ID, u.MaxRuns, u.UsageExpires)
+	return u.internals.check(ctx, ctx.Message.Author.ID, u.MaxRuns, u.UsageExpires)
 }
 
 // Clear is used to clear all cooldowns.
@@ -181,7 +182,7 @@ func (c *ChannelCooldown) Init() {
 
 // Check is used to check if the command should run and add 1 to the channel count.
 func (c *ChannelCooldown) Check(ctx *Context) (string, bool) {
-	return c.internals.check(ctx.Message.ChannelID, c.MaxRuns, c.UsageExpires)
+	return c.internals.check(ctx, ctx.Message.ChannelID, c.MaxRuns, c.UsageExpires)
 }
 
 // Clear is used to clear all cooldowns.
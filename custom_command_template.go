@@ -0,0 +1,188 @@
+package gommand
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// The maximum depth which tags can be nested within a template.
+const maxTemplateDepth = 10
+
+// The maximum number of characters a rendered template can be, which is the Discord message limit.
+const maxTemplateOutput = 2000
+
+// RenderTemplate is used to render a custom command template for the invocation with the arguments given.
+// The template language is deliberately simple: tags can only read information about the invocation and cannot run commands or access anything else.
+// The following tags are supported (tags which are not recognised are left as they are):
+//
+//	{user}, {user.name}, {user.tag}, {user.id} - the user who ran the command
+//	{channel}, {channel.id} - the channel the command was ran in
+//	{guild.id} - the guild the command was ran in
+//	{prefix} - the prefix which was used
+//	{args}, {args.count}, {args.N} - all of the arguments, the number of arguments, and the Nth argument (starting from 1)
+//	{choose:a|b|c} - one of the options at random
+//	{if:condition|then|else} - then if the condition is true, otherwise else (which is optional)
+//
+// A condition can be "a==b", "a!=b", or a value which is true if it is not blank (for example, "{if:{args.1}|...}").
+// Values from the invocation are never treated as part of the template, so arguments cannot add tags.
+// Note that the output is not sanitised; SanitiseMentions should be used before sending it.
+func RenderTemplate(ctx *Context, Template string, Args []string) string {
+	x := renderTemplate(ctx, Template, Args, 0)
+	if runes := []rune(x); len(runes) > maxTemplateOutput {
+		x = string(runes[:maxTemplateOutput])
+	}
+	return x
+}
+
+// SanitiseMentions is used to stop text from mentioning @everyone, @here or roles.
+func SanitiseMentions(Text string) string {
+	return strings.NewReplacer(
+		"@everyone", "@\u200beveryone",
+		"@here", "@\u200bhere",
+		"<@&", "<@\u200b&",
+	).Replace(Text)
+}
+
+// Renders the template at the nesting depth given.
+func renderTemplate(ctx *Context, t string, args []string, depth int) string {
+	b := strings.Builder{}
+	for i := 0; i < len(t); {
+		if t[i] == '{' {
+			if end := closingBrace(t, i); end != -1 {
+				b.WriteString(renderTag(ctx, t[i+1:end], args, depth))
+				i = end + 1
+				continue
+			}
+		}
+		b.WriteByte(t[i])
+		i++
+	}
+	return b.String()
+}
+
+// Gets the index of the brace which closes the one at the start index. If there isn't one, this returns -1.
+func closingBrace(t string, start int) int {
+	level := 0
+	for i := start; i < len(t); i++ {
+		switch t[i] {
+		case '{':
+			level++
+		case '}':
+			level--
+			if level == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Splits the text on the separator, ignoring any separators within tags.
+func splitOutsideTags(t, sep string) []string {
+	parts := []string{}
+	level := 0
+	last := 0
+	for i := 0; i < len(t); i++ {
+		switch t[i] {
+		case '{':
+			level++
+		case '}':
+			if level != 0 {
+				level--
+			}
+		default:
+			if level == 0 && strings.HasPrefix(t[i:], sep) {
+				parts = append(parts, t[last:i])
+				i += len(sep) - 1
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, t[last:])
+}
+
+// Renders the contents of a tag.
+func renderTag(ctx *Context, tag string, args []string, depth int) string {
+	literal := "{" + tag + "}"
+	if depth >= maxTemplateDepth {
+		return literal
+	}
+	split := strings.SplitN(tag, ":", 2)
+	if len(split) == 2 {
+		switch strings.ToLower(strings.Trim(split[0], " ")) {
+		case "choose":
+			options := splitOutsideTags(split[1], "|")
+			return renderTemplate(ctx, options[rand.Intn(len(options))], args, depth+1)
+		case "if":
+			parts := splitOutsideTags(split[1], "|")
+			if len(parts) < 2 {
+				return literal
+			}
+			if templateCondition(ctx, parts[0], args, depth+1) {
+				return renderTemplate(ctx, parts[1], args, depth+1)
+			}
+			if len(parts) > 2 {
+				return renderTemplate(ctx, strings.Join(parts[2:], "|"), args, depth+1)
+			}
+			return ""
+		}
+		return literal
+	}
+	value, ok := templateVariable(ctx, strings.ToLower(strings.Trim(tag, " ")), args)
+	if !ok {
+		return literal
+	}
+	return value
+}
+
+// Evaluates the condition of an if tag.
+func templateCondition(ctx *Context, condition string, args []string, depth int) bool {
+	for _, op := range []string{"!=", "=="} {
+		sides := splitOutsideTags(condition, op)
+		if len(sides) == 2 {
+			left := strings.Trim(renderTemplate(ctx, sides[0], args, depth), " ")
+			right := strings.Trim(renderTemplate(ctx, sides[1], args, depth), " ")
+			return strings.EqualFold(left, right) == (op == "==")
+		}
+	}
+	return strings.Trim(renderTemplate(ctx, condition, args, depth), " ") != ""
+}
+
+// Gets the value of a variable. If the variable doesn't exist, the boolean will be false.
+func templateVariable(ctx *Context, name string, args []string) (string, bool) {
+	msg := ctx.Message
+	switch name {
+	case "user":
+		return "<@" + msg.Author.ID.String() + ">", true
+	case "user.name":
+		return msg.Author.Username, true
+	case "user.tag":
+		return msg.Author.Tag(), true
+	case "user.id":
+		return msg.Author.ID.String(), true
+	case "channel":
+		return "<#" + msg.ChannelID.String() + ">", true
+	case "channel.id":
+		return msg.ChannelID.String(), true
+	case "guild.id":
+		return msg.GuildID.String(), true
+	case "prefix":
+		return ctx.Prefix, true
+	case "args":
+		return strings.Join(args, " "), true
+	case "args.count":
+		return strconv.Itoa(len(args)), true
+	}
+	if strings.HasPrefix(name, "args.") {
+		n, err := strconv.Atoi(name[5:])
+		if err != nil || n < 1 {
+			return "", false
+		}
+		if n > len(args) {
+			return "", true
+		}
+		return args[n-1], true
+	}
+	return "", false
+}
@@ -0,0 +1,277 @@
+package gommand
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/fastparse"
+)
+
+// The maximum number of characters in a custom command name.
+const maxCustomCommandName = 32
+
+// CustomCommand is a command which guild admins have added which replies with a template (see RenderTemplate for the template language).
+type CustomCommand struct {
+	// Name is the word which is used to run the custom command. This is lowercase.
+	Name string `json:"name"`
+
+	// Template is the template which is rendered to make the reply.
+	Template string `json:"template"`
+}
+
+// CustomCommandStorageAdapter is the interface which is used for storing the custom commands of each guild.
+type CustomCommandStorageAdapter interface {
+	// Called when the router is created.
+	Init()
+
+	// Get is used to get a custom command by its lowercase name. If it doesn't exist, this should be nil.
+	Get(GuildID disgord.Snowflake, Name string) (*CustomCommand, error)
+
+	// Set is used to add or replace a custom command.
+	Set(GuildID disgord.Snowflake, Value *CustomCommand) error
+
+	// Delete is used to delete a custom command by its lowercase name.
+	Delete(GuildID disgord.Snowflake, Name string) error
+
+	// List is used to get all of the custom commands of a guild.
+	List(GuildID disgord.Snowflake) ([]*CustomCommand, error)
+}
+
+// CustomCommandsConfig is used to configure the guild custom commands, as well as the built-in commands which are used to manage them.
+type CustomCommandsConfig struct {
+	// StorageAdapter is used to store the custom commands. nil will default to a InMemoryCustomCommandStorageAdapter.
+	StorageAdapter CustomCommandStorageAdapter
+
+	// MaxCommands is the maximum number of custom commands each guild can have. 0 will default to 50.
+	MaxCommands int
+
+	// MaxLength is the maximum number of characters in a template. 0 will default to 1000.
+	MaxLength int
+
+	// Category is the category of the custom command management commands. This can be nil.
+	Category CategoryInterface
+
+	// PermissionValidators is used to limit who can add, edit and remove custom commands. nil will default to users with the Manage Guild permission.
+	PermissionValidators []PermissionValidator
+
+	// DisableCommands is used to not add the custom command management commands.
+	DisableCommands bool
+}
+
+// Used to handle running custom commands from the storage adapter.
+func customCommandsHandler(ctx *Context, cmdname string, parser *fastparse.Parser) (bool, error) {
+	c, err := ctx.Router.CustomCommandStorageAdapter.Get(ctx.Message.GuildID, strings.ToLower(cmdname))
+	if err != nil || c == nil {
+		return false, err
+	}
+	args := []string{}
+	for arg := parser.GetNextArg(); arg != nil; arg = parser.GetNextArg() {
+		args = append(args, arg.Text)
+	}
+	content := SanitiseMentions(RenderTemplate(ctx, c.Template, args))
+	if strings.Trim(content, " \n") == "" {
+		return true, nil
+	}
+	_, err = ctx.Reply(&disgord.CreateMessageParams{
+		Content:         content,
+		AllowedMentions: &disgord.AllowedMentions{Parse: []string{"users"}},
+	})
+	return true, err
+}
+
+// Used to check a custom command can be created with the name, returning the message to send if it can't.
+// Custom commands run in every locale, so the name can't be the name of a command in any locale.
+func checkCustomCommandName(ctx *Context, Name string) (string, error) {
+	if utf8.RuneCountInString(Name) > maxCustomCommandName {
+		return ctx.Translate(MessageCustomCommandNameTooLong, "max", strconv.Itoa(maxCustomCommandName)), nil
+	}
+	if ctx.Router.getCommandInAnyLocale(Name) != nil {
+		return ctx.Translate(MessageCustomCommandConflict, "name", Name), nil
+	}
+	if ctx.Router.ShortcutStorageAdapter != nil {
+		shortcut, err := ctx.Router.ShortcutStorageAdapter.Get(ctx.Message.GuildID, Name)
+		if err != nil {
+			return "", err
+		}
+		if shortcut != nil {
+			return ctx.Translate(MessageCustomCommandConflict, "name", Name), nil
+		}
+	}
+	return "", nil
+}
+
+// Joins the names of the custom commands, leaving out any which would make the message longer than Discord allows.
+func customCommandList(ctx *Context, cmds []*CustomCommand) string {
+	list := ""
+	for i, v := range cmds {
+		name := "`" + ctx.Prefix + v.Name + "`"
+		if i != 0 {
+			name = ", " + name
+		}
+		more := ""
+		if i != len(cmds)-1 {
+			more = ", " + ctx.Translate(MessageCustomCommandListMore, "count", strconv.Itoa(len(cmds)-i-1))
+		}
+		if utf8.RuneCountInString(list+name+more) > maxTemplateOutput {
+			if list != "" {
+				list += ", "
+			}
+			return list + ctx.Translate(MessageCustomCommandListMore, "count", strconv.Itoa(len(cmds)-i))
+		}
+		list += name
+	}
+	return list
+}
+
+// Creates the custom command management commands.
+func customCommandCommands(Config *CustomCommandsConfig) *CommandGroup {
+	validators := Config.PermissionValidators
+	if validators == nil {
+		validators = []PermissionValidator{MANAGE_GUILD(CheckMembersUserPermissions)}
+	}
+	maxCommands := Config.MaxCommands
+	if maxCommands == 0 {
+		maxCommands = 50
+	}
+	maxLength := Config.MaxLength
+	if maxLength == 0 {
+		maxLength = 1000
+	}
+	templateArgs := []ArgTransformer{
+		{Function: StringTransformer},
+		{Function: StringTransformer, Remainder: true},
+	}
+
+	// Checks the template length, returning the message to send if it is too long.
+	checkLength := func(ctx *Context, Template string) string {
+		if utf8.RuneCountInString(Template) > maxLength {
+			return ctx.Translate(MessageCustomCommandTooLong, "max", strconv.Itoa(maxLength))
+		}
+		return ""
+	}
+
+	g := &CommandGroup{
+		Name:        "customcommand",
+		Aliases:     []string{"customcommands", "cc"},
+		Description: "Manages the custom commands in this guild.",
+		Category:    Config.Category,
+	}
+	g.AddCommand(&Command{
+		Name:                 "add",
+		Description:          "Adds a custom command which replies with the template given. Templates can use {user}, {channel}, {args}, {args.1}, {choose:a|b} and {if:{args.1}==a|then|else}.",
+		Usage:                "<name> <template>",
+		PermissionValidators: validators,
+		ArgTransformers:      templateArgs,
+		Function: func(ctx *Context) error {
+			name := strings.ToLower(ctx.Args[0].(string))
+			template := ctx.Args[1].(string)
+			msg, err := checkCustomCommandName(ctx, name)
+			if err != nil {
+				return err
+			}
+			if msg == "" {
+				msg = checkLength(ctx, template)
+			}
+			if msg != "" {
+				_, err = ctx.Reply(msg)
+				return err
+			}
+			existing, err := ctx.Router.CustomCommandStorageAdapter.Get(ctx.Message.GuildID, name)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				_, err = ctx.Reply(ctx.Translate(MessageCustomCommandExists, "name", name))
+				return err
+			}
+			cmds, err := ctx.Router.CustomCommandStorageAdapter.List(ctx.Message.GuildID)
+			if err != nil {
+				return err
+			}
+			if len(cmds) >= maxCommands {
+				_, err = ctx.Reply(ctx.Translate(MessageCustomCommandLimit, "max", strconv.Itoa(maxCommands)))
+				return err
+			}
+			err = ctx.Router.CustomCommandStorageAdapter.Set(ctx.Message.GuildID, &CustomCommand{Name: name, Template: template})
+			if err != nil {
+				return err
+			}
+			_, err = ctx.Reply(ctx.Translate(MessageCustomCommandAdded, "name", name))
+			return err
+		},
+	})
+	g.AddCommand(&Command{
+		Name:                 "edit",
+		Description:          "Changes the template of a custom command.",
+		Usage:                "<name> <template>",
+		PermissionValidators: validators,
+		ArgTransformers:      templateArgs,
+		Function: func(ctx *Context) error {
+			name := strings.ToLower(ctx.Args[0].(string))
+			template := ctx.Args[1].(string)
+			if msg := checkLength(ctx, template); msg != "" {
+				_, err := ctx.Reply(msg)
+				return err
+			}
+			existing, err := ctx.Router.CustomCommandStorageAdapter.Get(ctx.Message.GuildID, name)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				_, err = ctx.Reply(ctx.Translate(MessageCustomCommandNotFound, "name", name))
+				return err
+			}
+			err = ctx.Router.CustomCommandStorageAdapter.Set(ctx.Message.GuildID, &CustomCommand{Name: name, Template: template})
+			if err != nil {
+				return err
+			}
+			_, err = ctx.Reply(ctx.Translate(MessageCustomCommandEdited, "name", name))
+			return err
+		},
+	})
+	g.AddCommand(&Command{
+		Name:                 "remove",
+		Aliases:              []string{"delete"},
+		Description:          "Removes a custom command.",
+		Usage:                "<name>",
+		PermissionValidators: validators,
+		ArgTransformers: []ArgTransformer{
+			{Function: StringTransformer},
+		},
+		Function: func(ctx *Context) error {
+			name := strings.ToLower(ctx.Args[0].(string))
+			existing, err := ctx.Router.CustomCommandStorageAdapter.Get(ctx.Message.GuildID, name)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				_, err = ctx.Reply(ctx.Translate(MessageCustomCommandNotFound, "name", name))
+				return err
+			}
+			if err = ctx.Router.CustomCommandStorageAdapter.Delete(ctx.Message.GuildID, name); err != nil {
+				return err
+			}
+			_, err = ctx.Reply(ctx.Translate(MessageCustomCommandRemoved, "name", name))
+			return err
+		},
+	})
+	g.AddCommand(&Command{
+		Name:        "list",
+		Description: "Lists the custom commands in this guild.",
+		Function: func(ctx *Context) error {
+			cmds, err := ctx.Router.CustomCommandStorageAdapter.List(ctx.Message.GuildID)
+			if err != nil {
+				return err
+			}
+			if len(cmds) == 0 {
+				_, err = ctx.Reply(ctx.Translate(MessageCustomCommandNone))
+				return err
+			}
+			_, err = ctx.Reply(customCommandList(ctx, cmds))
+			return err
+		},
+	})
+	return g
+}
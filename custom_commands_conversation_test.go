@@ -0,0 +1,65 @@
+package gommand_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/auttaja/gommand"
+)
+
+// TestCustomCommandManagement is used to test adding, running and removing custom commands.
+func TestCustomCommandManagement(t *testing.T) {
+	r, h := newTestHarness(&gommand.RouterConfig{
+		CustomCommands: &gommand.CustomCommandsConfig{MaxCommands: 2, MaxLength: 100},
+		Shortcuts:      &gommand.ShortcutsConfig{},
+	})
+	r.SetCommand(replyCommand("ping", "Pong!"))
+	admin := h.Conversation(t, 31, 2)
+	user := h.Conversation(t, 30, 4)
+
+	// Only admins can add custom commands, and they can't replace commands.
+	user.Send("%cc add hi Hi {user}!")
+	user.Expect("You must have the \"Manage Guild\" permission to run this command.")
+	admin.Send("%cc add ping Pong?")
+	admin.Expect("There is already a command or shortcut called \"ping\".")
+	admin.Send("%cc add long " + strings.Repeat("a", 101))
+	admin.Expect("Custom commands cannot be longer than 100 characters.")
+	admin.Send("%customcommand add Hi Hi {user.name}{if:{args.1}| and {args}}!")
+	admin.Expect("Added the custom command \"hi\".")
+	admin.Send("%cc add hi Hello!")
+	admin.Expect("The custom command \"hi\" already exists. Use the edit command to change it.")
+	admin.Send("%cc add ping2 @everyone {args}")
+	admin.Expect("Added the custom command \"ping2\".")
+	admin.Send("%cc add third x")
+	admin.Expect("This guild has reached the limit of 2 custom commands.")
+	admin.Send("%cc list")
+	admin.Expect("`%hi`, `%ping2`")
+
+	// Shortcuts and custom commands can't hide each other.
+	admin.Send("%shortcut add hi ping")
+	admin.Expect("There is already a command called \"hi\".")
+	admin.Send("%shortcut add p ping")
+	admin.Expect("Added the shortcut \"p\".")
+	admin.Send("%cc add p Pong?")
+	admin.Expect("There is already a command or shortcut called \"p\".")
+
+	// Run the custom commands, which can't mention everyone.
+	user.Send("%hi")
+	user.Expect("Hi user!")
+	user.Send("%HI \"bob smith\"")
+	user.Expect("Hi user and bob smith!")
+	user.Send("%ping2 @here")
+	user.Expect("@\u200beveryone @\u200bhere")
+
+	// Edit and remove the custom commands.
+	admin.Send("%cc edit hi Hello!")
+	admin.Expect("Edited the custom command \"hi\".")
+	user.Send("%hi")
+	user.Expect("Hello!")
+	admin.Send("%cc remove hi")
+	admin.Expect("Removed the custom command \"hi\".")
+	admin.Send("%cc edit hi Hello!")
+	admin.Expect("The custom command \"hi\" does not exist.")
+	user.Send("%hi")
+	user.Expect("The command \"hi\" does not exist.")
+}
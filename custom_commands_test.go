@@ -1,8 +1,12 @@
 package gommand
 
 import (
+	"github.com/andersfylling/disgord"
 	"github.com/auttaja/fastparse"
+	"strconv"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 // TestCustomCommands is used to test custom commands.
@@ -39,3 +43,77 @@ func TestCustomCommands(t *testing.T) {
 	exists = true
 	r.CommandProcessor(nil, 0, mockMessage("%test 123"), true)
 }
+
+// TestCustomCommandNames is used to test that custom command names are limited in length and can't be the name of a command in any locale.
+func TestCustomCommandNames(t *testing.T) {
+	r := NewRouter(&RouterConfig{})
+	cmd := noopCommand("hello")
+	cmd.Localisations = map[string]*CommandLocalisation{"fr": {Name: "bonjour"}}
+	r.SetCommand(cmd)
+	ctx := &Context{Router: r, Message: mockMessage("")}
+	for _, v := range []string{"hello", "bonjour", strings.Repeat("a", maxCustomCommandName+1)} {
+		if msg, err := checkCustomCommandName(ctx, v); err != nil || msg == "" {
+			t.Fatal("the custom command name was allowed:", v)
+		}
+	}
+	if msg, err := checkCustomCommandName(ctx, strings.Repeat("a", maxCustomCommandName)); err != nil || msg != "" {
+		t.Fatal("the custom command name was not allowed:", msg, err)
+	}
+}
+
+// TestCustomCommandList is used to test that the custom command list fits in a message.
+func TestCustomCommandList(t *testing.T) {
+	ctx := &Context{Prefix: "%"}
+	cmds := []*CustomCommand{{Name: "a"}, {Name: "b"}}
+	if list := customCommandList(ctx, cmds); list != "`%a`, `%b`" {
+		t.Fatal("unexpected list:", list)
+	}
+	cmds = make([]*CustomCommand, 100)
+	for i := range cmds {
+		cmds[i] = &CustomCommand{Name: strings.Repeat("a", maxCustomCommandName-3) + strconv.Itoa(i)}
+	}
+	list := customCommandList(ctx, cmds)
+	if utf8.RuneCountInString(list) > maxTemplateOutput || !strings.HasSuffix(list, " more") {
+		t.Fatal("the list was not truncated:", utf8.RuneCountInString(list))
+	}
+}
+
+// TestRenderTemplate is used to test rendering custom command templates.
+func TestRenderTemplate(t *testing.T) {
+	msg := mockMessage("")
+	msg.Author = &disgord.User{ID: 5, Username: "jake", Discriminator: 1}
+	msg.ChannelID = 6
+	ctx := &Context{Message: msg, Prefix: "%"}
+	tests := []struct {
+		template string
+		args     []string
+		expected string
+	}{
+		{"Hello {user}!", nil, "Hello <@5>!"},
+		{"{user.name} ({user.tag}) in {channel}", nil, "jake (jake#0001) in <#6>"},
+		{"{args} / {args.2} / {args.count} / [{args.3}]", []string{"a", "b"}, "a b / b / 2 / []"},
+		{"{if:{args.1}|yes|no}", nil, "no"},
+		{"{if:{args.1}|yes|no}", []string{"x"}, "yes"},
+		{"{if:{args.1}==hi|hello {user.name}|bye}", []string{"HI"}, "hello jake"},
+		{"{if:{args.1}!=hi|not hi}", []string{"hi"}, ""},
+		{"{choose:{user.name}}", nil, "jake"},
+		{"{args.1}", []string{"{user.id}"}, "{user.id}"},
+		{"{unknown} {if:x} {prefix}", nil, "{unknown} {if:x} %"},
+		{"{nope", nil, "{nope"},
+	}
+	for _, v := range tests {
+		if res := RenderTemplate(ctx, v.template, v.args); res != v.expected {
+			t.Errorf("%q rendered to %q, expected %q", v.template, res, v.expected)
+		}
+	}
+	if res := RenderTemplate(ctx, "{choose:a|b|c}", nil); res != "a" && res != "b" && res != "c" {
+		t.Error("invalid choice:", res)
+	}
+	nested := strings.Repeat("{if:x|", 20) + "deep" + strings.Repeat("}", 20)
+	if res := RenderTemplate(ctx, nested, nil); res != strings.Repeat("{if:x|", 10)+"deep"+strings.Repeat("}", 10) {
+		t.Error("nesting limit was not applied:", res)
+	}
+	if res := SanitiseMentions("@everyone @here <@&1> <@2>"); res != "@\u200beveryone @\u200bhere <@\u200b&1> <@2>" {
+		t.Error("mentions were not sanitised:", res)
+	}
+}
@@ -1,129 +1,499 @@
 package gommand
 
 import (
-	"github.com/andersfylling/disgord"
-	"math"
+	"context"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/andersfylling/disgord"
 )
 
-// Creates the embeds for the categories.
-func createCategoryEmbeds(ctx *Context, key CategoryInterface, value []CommandInterface) []*disgord.Embed {
-	// Defines the number of fields per page.
-	FieldsPerPage := 5
+// HelpConfig is used to configure the built-in help command.
+type HelpConfig struct {
+	// Disabled is used to stop the built-in help command from being installed.
+	Disabled bool
+
+	// PageSize is the number of commands on each page. 0 will default to 5.
+	PageSize int
+
+	// Colour is the colour of the help embeds. 0 will default to 2818303.
+	Colour int
+
+	// ErrorColour is the colour of the embed when a command is not found. 0 will default to 16711704.
+	ErrorColour int
+
+	// SortCategories is used to order the categories. Uncategorised commands have a nil category.
+	// nil will order the categories by name with uncategorised commands first.
+	SortCategories func(a, b CategoryInterface) bool
+
+	// SortCommands is used to order the commands within a category. nil will order the commands by name.
+	SortCommands func(a, b CommandInterface) bool
+
+	// HiddenCommands is the names of commands which will not be shown in the help command. Commands can also be hidden from everyone but the bot owners with the Hidden flag.
+	HiddenCommands []string
+
+	// HiddenCategories is the names of categories which will not be shown in the help command.
+	HiddenCategories []string
+
+	// TextFallback is used to send the help as text when the bot cannot send embeds in the channel rather than requiring the embed links permission.
+	TextFallback bool
+
+	// Formatter is used to render the help. nil will default to a DefaultHelpFormatter using the colours above.
+	Formatter HelpFormatter
+}
+
+// HelpPage is a page of commands within a category which is being rendered by the help command.
+type HelpPage struct {
+	// Category is the category of the commands. This is nil for uncategorised commands.
+	Category CategoryInterface
+
+	// Commands is the commands on this page which the user can run.
+	Commands []CommandInterface
+
+	// Page is the number of this page within the category, starting at 1.
+	Page int
+
+	// Pages is the number of pages within the category.
+	Pages int
+}
+
+// HelpShortcutsPage is a page of the guild shortcuts which is being rendered by the help command.
+type HelpShortcutsPage struct {
+	// Shortcuts is the shortcuts on this page.
+	Shortcuts []*Shortcut
+
+	// Page is the number of this page within the shortcuts, starting at 1.
+	Page int
+
+	// Pages is the number of pages of shortcuts.
+	Pages int
+}
+
+// HelpShortcutsFormatter is an optional interface which help formatters can implement to render the guild shortcuts.
+// If the formatter does not implement this, the shortcuts are rendered with the default formatter.
+type HelpShortcutsFormatter interface {
+	FormatShortcuts(ctx *Context, Page *HelpShortcutsPage) *disgord.Embed
+}
+
+// HelpCommandInfo is the information about a single command which is being rendered by the help command.
+type HelpCommandInfo struct {
+	// Path is the name of the command including any command groups (for example, "config prefix").
+	Path string
+
+	// Command is the command.
+	Command CommandInterface
+
+	// HasPermission defines if the user has permission to run the command.
+	HasPermission bool
+
+	// Subcommands is the sub-commands which the user can run if the command is a command group.
+	Subcommands []CommandInterface
+}
+
+// HelpFormatter is used to render the help command. The help command handles getting the commands, ordering and pagination.
+// When the text fallback is used, the embeds are converted into text.
+type HelpFormatter interface {
+	// FormatPage is used to render a page of commands within a category.
+	FormatPage(ctx *Context, Page *HelpPage) *disgord.Embed
+
+	// FormatCommand is used to render the help for a single command.
+	FormatCommand(ctx *Context, Info *HelpCommandInfo) *disgord.Embed
+
+	// FormatNotFound is used to render the message when the command the user wanted help for was not found.
+	FormatNotFound(ctx *Context, Path string) *disgord.Embed
+}
+
+// DefaultHelpFormatter is the formatter which is used by default for the help command.
+type DefaultHelpFormatter struct {
+	// Colour is the colour of the embeds.
+	Colour int
 
-	// Ignore commands which the user can't run.
-	cmds := make([]CommandInterface, 0, len(value))
-	for _, v := range value {
-		if CommandHasPermission(ctx, v) == nil {
-			cmds = append(cmds, v)
+	// ErrorColour is the colour of the embed when a command is not found.
+	ErrorColour int
+}
+
+// Creates the field for a command.
+func helpCommandField(ctx *Context, Path string, cmd CommandInterface) *disgord.EmbedField {
+	Description := LocalisedDescription(cmd, ctx.Locale())
+	if Description == "" {
+		Description = ctx.Translate(MessageHelpNoDescription)
+	}
+	return &disgord.EmbedField{
+		Name:   ctx.Prefix + Path + " " + LocalisedUsage(cmd, ctx.Locale()),
+		Value:  Description,
+		Inline: false,
+	}
+}
+
+// FormatPage is used to render a page of commands within a category.
+func (f *DefaultHelpFormatter) FormatPage(ctx *Context, Page *HelpPage) *disgord.Embed {
+	Title := ""
+	Description := ""
+	if Page.Category == nil {
+		Title = ctx.Translate(MessageHelpGeneralCommands) + " "
+		Description = ctx.Translate(MessageHelpGeneralDescription)
+	} else {
+		Title = Page.Category.GetName() + " "
+		Description = Page.Category.GetDescription()
+	}
+	Title += "[" + strconv.Itoa(Page.Page) + "/" + strconv.Itoa(Page.Pages) + "]"
+	Fields := make([]*disgord.EmbedField, len(Page.Commands))
+	for i, v := range Page.Commands {
+		Fields[i] = helpCommandField(ctx, LocalisedName(v, ctx.Locale()), v)
+	}
+	return &disgord.Embed{
+		Title:       Title,
+		Description: Description,
+		Color:       f.Colour,
+		Fields:      Fields,
+	}
+}
+
+// FormatCommand is used to render the help for a single command.
+func (f *DefaultHelpFormatter) FormatCommand(ctx *Context, Info *HelpCommandInfo) *disgord.Embed {
+	desc := LocalisedDescription(Info.Command, ctx.Locale())
+	if !Info.HasPermission {
+		desc += "\n\n" + ctx.Translate(MessageHelpNoPermission)
+	}
+	embed := &disgord.Embed{
+		Title:       ctx.Prefix + Info.Path + " " + LocalisedUsage(Info.Command, ctx.Locale()),
+		Description: desc,
+		Color:       f.Colour,
+	}
+	for _, v := range Info.Subcommands {
+		embed.Fields = append(embed.Fields, helpCommandField(ctx, Info.Path+" "+LocalisedName(v, ctx.Locale()), v))
+	}
+	return embed
+}
+
+// FormatShortcuts is used to render a page of the guild shortcuts.
+func (f *DefaultHelpFormatter) FormatShortcuts(ctx *Context, Page *HelpShortcutsPage) *disgord.Embed {
+	Fields := make([]*disgord.EmbedField, len(Page.Shortcuts))
+	for i, v := range Page.Shortcuts {
+		Fields[i] = &disgord.EmbedField{
+			Name:   ctx.Prefix + v.Name,
+			Value:  ctx.Translate(MessageShortcutDescription, "command", strings.Trim(v.Command+" "+v.Args, " ")),
+			Inline: false,
 		}
 	}
-	if len(cmds) == 0 {
-		return []*disgord.Embed{}
+	return &disgord.Embed{
+		Title:       ctx.Translate(MessageHelpShortcuts) + " [" + strconv.Itoa(Page.Page) + "/" + strconv.Itoa(Page.Pages) + "]",
+		Description: ctx.Translate(MessageHelpShortcutsDescription),
+		Color:       f.Colour,
+		Fields:      Fields,
 	}
+}
+
+// FormatNotFound is used to render the message when the command the user wanted help for was not found.
+func (f *DefaultHelpFormatter) FormatNotFound(ctx *Context, Path string) *disgord.Embed {
+	return &disgord.Embed{
+		Title:       ctx.Translate(MessageHelpNotFoundTitle),
+		Description: ctx.Translate(MessageHelpNotFound, "command", Path),
+		Color:       f.ErrorColour,
+	}
+}
+
+// Converts an embed to text for when the bot cannot send embeds.
+func helpEmbedText(embed *disgord.Embed) string {
+	parts := make([]string, 0, len(embed.Fields)+2)
+	if embed.Title != "" {
+		parts = append(parts, "**"+embed.Title+"**")
+	}
+	if embed.Description != "" {
+		parts = append(parts, embed.Description)
+	}
+	for _, v := range embed.Fields {
+		parts = append(parts, "**"+v.Name+"**\n"+v.Value)
+	}
+	if embed.Footer != nil && embed.Footer.Text != "" {
+		parts = append(parts, embed.Footer.Text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Checks if the bot can send embeds in the channel.
+func canSendEmbeds(ctx *Context) (bool, error) {
+	c, err := ctx.Channel()
+	if err != nil {
+		return false, err
+	}
+	m, err := ctx.BotMember()
+	if err != nil {
+		return false, err
+	}
+	perms, err := c.GetPermissions(context.TODO(), ctx.Session, m)
+	if err != nil {
+		return false, err
+	}
+	return (perms&disgord.PermissionAdministrator) == disgord.PermissionAdministrator || (perms&disgord.PermissionEmbedLinks) == disgord.PermissionEmbedLinks, nil
+}
+
+// Used to handle the built-in help command with the config.
+type helpHandler struct {
+	config *HelpConfig
+}
 
-	// Creates an array full of Discord embeds.
-	EmbedsLen := int(math.Ceil(float64(len(cmds)) / float64(FieldsPerPage)))
-	Fields := make([][]*disgord.EmbedField, EmbedsLen)
-	CurrentField := 0
-	current := make([]*disgord.EmbedField, 0, FieldsPerPage)
-	for i, v := range cmds {
-		if i != 0 && i%FieldsPerPage == 0 {
-			Fields[CurrentField] = current
-			CurrentField++
-			current = make([]*disgord.EmbedField, 0, FieldsPerPage)
+// Fills in the defaults for the config.
+func newHelpHandler(Config *HelpConfig) *helpHandler {
+	c := *Config
+	if c.PageSize <= 0 {
+		c.PageSize = 5
+	}
+	if c.Colour == 0 {
+		c.Colour = 2818303
+	}
+	if c.ErrorColour == 0 {
+		c.ErrorColour = 16711704
+	}
+	if c.SortCategories == nil {
+		c.SortCategories = func(a, b CategoryInterface) bool {
+			if a == nil || b == nil {
+				return a == nil && b != nil
+			}
+			return a.GetName() < b.GetName()
 		}
-		Description := v.GetDescription()
-		if Description == "" {
-			Description = "No description set."
+	}
+	if c.SortCommands == nil {
+		c.SortCommands = func(a, b CommandInterface) bool {
+			return a.GetName() < b.GetName()
 		}
-		current = append(current, &disgord.EmbedField{
-			Name:   ctx.Prefix + v.GetName() + " " + v.GetUsage(),
-			Value:  Description,
-			Inline: false,
+	}
+	if c.Formatter == nil {
+		c.Formatter = &DefaultHelpFormatter{Colour: c.Colour, ErrorColour: c.ErrorColour}
+	}
+	return &helpHandler{config: &c}
+}
+
+// Checks if the name is within the hidden names.
+func helpNameHidden(Name string, Hidden []string) bool {
+	for _, v := range Hidden {
+		if strings.EqualFold(v, Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HiddenProvider is an optional interface which commands and categories can implement to hide themselves from the help command for everyone but the bot owners.
+type HiddenProvider interface {
+	GetHidden() bool
+}
+
+// Checks if the command or category is hidden with the Hidden flag.
+func hiddenFlag(x interface{}) bool {
+	p, ok := x.(HiddenProvider)
+	return ok && p.GetHidden()
+}
+
+// Checks if the command should be hidden from the help command for the user.
+func (h *helpHandler) hidden(ctx *Context, cmd CommandInterface) bool {
+	cat := cmd.GetCategory()
+	if helpNameHidden(cmd.GetName(), h.config.HiddenCommands) || (cat != nil && helpNameHidden(cat.GetName(), h.config.HiddenCategories)) {
+		return true
+	}
+	if hiddenFlag(cmd) || (cat != nil && hiddenFlag(cat)) {
+		return ctx.Message == nil || ctx.Message.Author == nil || !ctx.Router.IsOwner(ctx.Message.Author.ID)
+	}
+	return false
+}
+
+// Creates the pages of commands which the user can run.
+func (h *helpHandler) createPages(ctx *Context) []*disgord.Embed {
+	// Order the categories.
+	ordered := ctx.Router.GetCommandsOrderedByCategory()
+	cats := make([]CategoryInterface, 0, len(ordered))
+	for k := range ordered {
+		cats = append(cats, k)
+	}
+	sort.SliceStable(cats, func(i, j int) bool {
+		return h.config.SortCategories(cats[i], cats[j])
+	})
+
+	// Create the pages for each category.
+	pages := make([]*disgord.Embed, 0)
+	for _, cat := range cats {
+		// Ignore commands which are hidden or the user can't run.
+		cmds := make([]CommandInterface, 0, len(ordered[cat]))
+		for _, v := range ordered[cat] {
+			if !h.hidden(ctx, v) && CommandHasPermission(ctx, v) == nil {
+				cmds = append(cmds, v)
+			}
+		}
+		sort.SliceStable(cmds, func(i, j int) bool {
+			return h.config.SortCommands(cmds[i], cmds[j])
 		})
+
+		// Split the commands into pages.
+		count := (len(cmds) + h.config.PageSize - 1) / h.config.PageSize
+		for i := 0; i < count; i++ {
+			end := (i + 1) * h.config.PageSize
+			if end > len(cmds) {
+				end = len(cmds)
+			}
+			pages = append(pages, h.config.Formatter.FormatPage(ctx, &HelpPage{
+				Category: cat,
+				Commands: cmds[i*h.config.PageSize : end],
+				Page:     i + 1,
+				Pages:    count,
+			}))
+		}
+	}
+	return append(pages, h.createShortcutPages(ctx)...)
+}
+
+// Creates the pages of the guild shortcuts if there are any.
+func (h *helpHandler) createShortcutPages(ctx *Context) []*disgord.Embed {
+	if ctx.Router.ShortcutStorageAdapter == nil || ctx.Message == nil {
+		return nil
+	}
+	shortcuts, err := ctx.Router.ShortcutStorageAdapter.List(ctx.Message.GuildID)
+	if err != nil || len(shortcuts) == 0 {
+		return nil
+	}
+	formatter, ok := h.config.Formatter.(HelpShortcutsFormatter)
+	if !ok {
+		formatter = &DefaultHelpFormatter{Colour: h.config.Colour, ErrorColour: h.config.ErrorColour}
 	}
-	if len(current) != 0 {
-		Fields[CurrentField] = current
-	}
-	Embeds := make([]*disgord.Embed, EmbedsLen)
-	for i := range Embeds {
-		Title := ""
-		Description := ""
-		if key == nil {
-			Title = "General Commands "
-			Description = "These commands have not been assigned a category yet."
-		} else {
-			Title = key.GetName() + " "
-			Description = key.GetDescription()
+	count := (len(shortcuts) + h.config.PageSize - 1) / h.config.PageSize
+	pages := make([]*disgord.Embed, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * h.config.PageSize
+		if end > len(shortcuts) {
+			end = len(shortcuts)
 		}
-		Title += "[" + strconv.Itoa(i+1) + "/" + strconv.Itoa(EmbedsLen) + "]"
-		Embeds[i] = &disgord.Embed{
-			Title:       Title,
-			Description: Description,
-			Color:       2818303,
-			Fields:      Fields[i],
+		pages[i] = formatter.FormatShortcuts(ctx, &HelpShortcutsPage{
+			Shortcuts: shortcuts[i*h.config.PageSize : end],
+			Page:      i + 1,
+			Pages:     count,
+		})
+	}
+	return pages
+}
+
+// Creates the embed for a command. Path is the names of the command and any sub-commands which were given.
+// If the command is a command group, the sub-commands which the user can run are listed.
+func (h *helpHandler) createCommandEmbed(ctx *Context, Path []string) *disgord.Embed {
+	cmdname := strings.ToLower(strings.Join(Path, " "))
+	notFound := func() *disgord.Embed {
+		return h.config.Formatter.FormatNotFound(ctx, cmdname)
+	}
+
+	// Walk the command groups to get the command.
+	locale := ctx.Locale()
+	cmd := ctx.Router.GetLocalisedCommand(locale, Path[0])
+	if cmd == nil || h.hidden(ctx, cmd) {
+		return notFound()
+	}
+	var inherited CommandInterface = cmd
+	for _, name := range Path[1:] {
+		g, ok := cmd.(*CommandGroup)
+		if !ok {
+			return notFound()
 		}
+		cmd = g.GetLocalisedCommand(locale, name)
+		if cmd == nil || h.hidden(ctx, cmd) {
+			return notFound()
+		}
+		inherited = &inheritedCommand{CommandInterface: cmd, parent: inherited}
 	}
 
-	// Return the embeds.
-	return Embeds
+	// Get the sub-commands which the user can run.
+	info := &HelpCommandInfo{
+		Path:          cmdname,
+		Command:       cmd,
+		HasPermission: CommandHasPermission(ctx, inherited) == nil,
+	}
+	if g, ok := cmd.(*CommandGroup); ok {
+		for _, v := range g.GetSubcommands() {
+			if !h.hidden(ctx, v) && CommandHasPermission(ctx, &inheritedCommand{CommandInterface: v, parent: inherited}) == nil {
+				info.Subcommands = append(info.Subcommands, v)
+			}
+		}
+		sort.SliceStable(info.Subcommands, func(i, j int) bool {
+			return h.config.SortCommands(info.Subcommands[i], info.Subcommands[j])
+		})
+	}
+	return h.config.Formatter.FormatCommand(ctx, info)
+}
+
+// Sends the embed, falling back to text if this is enabled.
+func (h *helpHandler) send(ctx *Context, embed *disgord.Embed) {
+	if h.config.TextFallback {
+		_, _ = ctx.EmbedTextFailover(func() *disgord.Embed {
+			return embed
+		}, func() string {
+			return helpEmbedText(embed)
+		})
+		return
+	}
+	_, _ = ctx.Reply(embed)
+}
+
+// Sends the pages, falling back to sending the page as text if this is enabled.
+func (h *helpHandler) sendPages(ctx *Context, pages []*disgord.Embed, page uint) error {
+	footer := ctx.Translate(MessageHelpPages, "prefix", ctx.Prefix)
+	if h.config.TextFallback && len(pages) != 0 {
+		ok, err := canSendEmbeds(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if page == 0 || int(page) > len(pages) {
+				page = 1
+			}
+			embed := disgord.DeepCopy(pages[page-1]).(*disgord.Embed)
+			embed.Footer = &disgord.EmbedFooter{Text: ctx.Translate(MessagePageFooter, "page", strconv.Itoa(int(page)), "pages", strconv.Itoa(len(pages))) + " - " + footer}
+			_, err = ctx.Reply(helpEmbedText(embed))
+			return err
+		}
+	}
+	return EmbedsPaginatorWithLifetime(ctx, pages, page, footer, &EmbedLifetimeOptions{InactiveLifetime: time.Minute * 5})
 }
 
 // This sets the default help command.
-func defaultHelpCommand() *Command {
+func defaultHelpCommand(Config *HelpConfig) *Command {
+	h := newHelpHandler(Config)
+	var validators []PermissionValidator
+	if !h.config.TextFallback {
+		validators = []PermissionValidator{
+			EMBED_LINKS(CheckBotChannelPermissions),
+		}
+	}
 	return &Command{
 		Name:        "help",
 		Description: "Used to get help for a command.",
-		Usage:       "[page/command]",
+		Usage:       "[page/command] [sub-command...]",
 		ArgTransformers: []ArgTransformer{
 			{
 				Optional: true,
 				Function: AnyTransformer(UIntTransformer, StringTransformer),
 			},
+			{
+				Optional:  true,
+				Remainder: true,
+				Function:  StringTransformer,
+			},
 		},
-		PermissionValidators: []PermissionValidator{
-			EMBED_LINKS(CheckBotChannelPermissions),
-		},
+		PermissionValidators: validators,
 		Function: func(ctx *Context) error {
 			// Get a single command if it is set.
 			cmdname, ok := ctx.Args[0].(string)
 			if ok {
-				cmdname = strings.ToLower(cmdname)
-				cmd := ctx.Router.GetCommand(cmdname)
-				if cmd == nil {
-					_, _ = ctx.Reply(disgord.Embed{
-						Title:       "Command not found:",
-						Description: "The command \"" + cmdname + "\" was not found.",
-						Color:       16711704,
-					})
-					return nil
-				}
-				desc := cmd.GetDescription()
-				if CommandHasPermission(ctx, cmd) != nil {
-					desc += "\n\n**You do not have permission to run this.**"
+				path := []string{cmdname}
+				if subcommands, ok := ctx.Args[1].(string); ok {
+					path = append(path, strings.Fields(subcommands)...)
 				}
-				_, _ = ctx.Reply(disgord.Embed{
-					Title:       ctx.Prefix + cmdname + " " + cmd.GetUsage(),
-					Description: desc,
-					Color:       2818303,
-				})
+				h.send(ctx, h.createCommandEmbed(ctx, path))
 				return nil
 			}
 
 			// Get the page if it is set. If not, this will default to 0 which is handled.
 			page, _ := ctx.Args[0].(uint64)
 
-			// Get the embed pages.
-			pages := make([]*disgord.Embed, 0)
-			for k, v := range ctx.Router.GetCommandsOrderedByCategory() {
-				pages = append(pages, createCategoryEmbeds(ctx, k, v)...)
-			}
-
 			// Send the embed pages.
-			_ = EmbedsPaginatorWithLifetime(ctx, pages, uint(page), "Use "+ctx.Prefix+"help <page number> to flick between pages.", &EmbedLifetimeOptions{InactiveLifetime: time.Minute * 5})
+			_ = h.sendPages(ctx, h.createPages(ctx), uint(page))
 
 			// Return no errors.
 			return nil
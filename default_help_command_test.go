@@ -0,0 +1,159 @@
+package gommand
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+)
+
+// TestNestedHelp is used to test getting help for sub-commands.
+func TestNestedHelp(t *testing.T) {
+	r := NewRouter(&RouterConfig{})
+	deny := func(ctx *Context) (string, bool) {
+		return "denied", false
+	}
+	inner := &CommandGroup{Name: "inner", Description: "The inner group."}
+	inner.AddCommand(&Command{Name: "leaf", Usage: "<thing>", Description: "A leaf."})
+	inner.AddCommand(&Command{Name: "secret", PermissionValidators: []PermissionValidator{deny}})
+	outer := &CommandGroup{Name: "outer", Aliases: []string{"o"}}
+	outer.AddCommand(inner)
+	outer.AddCommand(&Command{Name: "other"})
+	r.SetCommand(outer)
+	r.SetCommand(&CommandGroup{Name: "locked", PermissionValidators: []PermissionValidator{deny}, subcommands: map[string]CommandInterface{
+		"sub": &Command{Name: "sub"},
+	}})
+	ctx := &Context{Router: r, Message: mockMessage(""), Prefix: "%"}
+	h := newHelpHandler(&HelpConfig{})
+
+	// Check the group pages list the sub-commands.
+	embed := h.createCommandEmbed(ctx, []string{"O"})
+	if embed.Title != "%o <inner/other>" || len(embed.Fields) != 2 {
+		t.Fatal("unexpected outer group embed:", embed)
+	}
+	if embed.Fields[0].Name != "%o inner <leaf/secret>" || embed.Fields[0].Value != "The inner group." {
+		t.Fatal("unexpected field:", embed.Fields[0].Name, embed.Fields[0].Value)
+	}
+	embed = h.createCommandEmbed(ctx, []string{"outer", "inner"})
+	if len(embed.Fields) != 1 || embed.Fields[0].Name != "%outer inner leaf <thing>" {
+		t.Fatal("sub-commands the user can't run should be hidden:", embed)
+	}
+
+	// Check sub-commands at any depth.
+	embed = h.createCommandEmbed(ctx, []string{"outer", "inner", "leaf"})
+	if embed.Title != "%outer inner leaf <thing>" || embed.Description != "A leaf." {
+		t.Fatal("unexpected leaf embed:", embed)
+	}
+	embed = h.createCommandEmbed(ctx, []string{"locked", "sub"})
+	if embed.Description != "\n\n**You do not have permission to run this.**" {
+		t.Fatal("the sub-command should inherit the group permissions:", embed)
+	}
+	for _, v := range [][]string{{"outer", "missing"}, {"outer", "other", "x"}} {
+		embed = h.createCommandEmbed(ctx, v)
+		if embed.Title != "Command not found:" || embed.Description != "The command \""+strings.Join(v, " ")+"\" was not found." {
+			t.Fatal("unexpected not found embed:", embed)
+		}
+	}
+}
+
+// Used to test replacing the help formatter.
+type testHelpFormatter struct{}
+
+func (testHelpFormatter) FormatPage(_ *Context, Page *HelpPage) *disgord.Embed {
+	names := make([]string, len(Page.Commands))
+	for i, v := range Page.Commands {
+		names[i] = v.GetName()
+	}
+	title := "none"
+	if Page.Category != nil {
+		title = Page.Category.GetName()
+	}
+	return &disgord.Embed{Title: title, Description: strings.Join(names, ",")}
+}
+
+func (testHelpFormatter) FormatCommand(_ *Context, Info *HelpCommandInfo) *disgord.Embed {
+	return &disgord.Embed{Title: Info.Path}
+}
+
+func (testHelpFormatter) FormatNotFound(_ *Context, Path string) *disgord.Embed {
+	return &disgord.Embed{Title: "missing " + Path}
+}
+
+// TestHelpConfig is used to test configuring the help command.
+func TestHelpConfig(t *testing.T) {
+	if NewRouter(&RouterConfig{Help: &HelpConfig{Disabled: true}}).GetCommand("help") != nil {
+		t.Fatal("the help command should not be installed")
+	}
+	r := NewRouter(&RouterConfig{})
+	if r.GetCommand("help").GetPermissionValidators() == nil {
+		t.Fatal("the help command should require embed links by default")
+	}
+	r.RemoveCommand(r.GetCommand("help"))
+	a, b, hidden := &Category{Name: "a"}, &Category{Name: "b"}, &Category{Name: "hidden"}
+	for _, v := range []*Command{
+		{Name: "e", Category: a}, {Name: "d", Category: a}, {Name: "c", Category: a},
+		{Name: "f", Category: b}, {Name: "secret", Category: b},
+		{Name: "g", Category: hidden}, {Name: "h"},
+	} {
+		r.SetCommand(v)
+	}
+	ctx := &Context{Router: r, Message: mockMessage("")}
+
+	// Check the ordering, page size and hidden commands.
+	h := newHelpHandler(&HelpConfig{
+		PageSize:         2,
+		HiddenCommands:   []string{"Secret"},
+		HiddenCategories: []string{"hidden"},
+		SortCategories: func(a, b CategoryInterface) bool {
+			return a != nil && (b == nil || a.GetName() > b.GetName())
+		},
+		Formatter: testHelpFormatter{},
+	})
+	pages := h.createPages(ctx)
+	titles := make([]string, len(pages))
+	for i, v := range pages {
+		titles[i] = v.Title + ":" + v.Description
+	}
+	expected := "b:f a:c,d a:e none:h"
+	if strings.Join(titles, " ") != expected {
+		t.Fatalf("expected %s, got %s", expected, strings.Join(titles, " "))
+	}
+	if h.createCommandEmbed(ctx, []string{"SECRET"}).Title != "missing secret" || h.createCommandEmbed(ctx, []string{"c"}).Title != "c" {
+		t.Fatal("hidden commands should not be found")
+	}
+
+	// Check the default formatter uses the colours and the text fallback.
+	h = newHelpHandler(&HelpConfig{Colour: 1, ErrorColour: 2, TextFallback: true})
+	if h.createPages(ctx)[0].Color != 1 || h.createCommandEmbed(ctx, []string{"x"}).Color != 2 {
+		t.Fatal("the colours were not used")
+	}
+	if defaultHelpCommand(&HelpConfig{TextFallback: true}).PermissionValidators != nil {
+		t.Fatal("the text fallback should not require embed links")
+	}
+	text := helpEmbedText(h.createPages(ctx)[0])
+	if text != "**General Commands [1/1]**\n\nThese commands have not been assigned a category yet.\n\n**h **\nNo description set." {
+		t.Fatal("unexpected text:", text)
+	}
+}
+
+// TestHelpShortcuts is used to test listing the guild shortcuts in the help command.
+func TestHelpShortcuts(t *testing.T) {
+	r := NewRouter(&RouterConfig{
+		Help:      &HelpConfig{TextFallback: true, PageSize: 1},
+		Shortcuts: &ShortcutsConfig{DisableCommands: true},
+	})
+	ctx := &Context{Router: r, Message: mockMessage(""), Prefix: "%"}
+	h := newHelpHandler(&HelpConfig{PageSize: 1})
+	if pages := h.createPages(ctx); len(pages) != 1 {
+		t.Fatal("there should be no shortcut pages:", pages)
+	}
+	_ = r.ShortcutStorageAdapter.Set(ctx.Message.GuildID, &Shortcut{Name: "b", Command: "help"})
+	_ = r.ShortcutStorageAdapter.Set(ctx.Message.GuildID, &Shortcut{Name: "a", Command: "mute", Args: "{args} 10m"})
+	pages := h.createPages(ctx)
+	if len(pages) != 3 || pages[1].Title != "Guild shortcuts [1/2]" || pages[2].Title != "Guild shortcuts [2/2]" {
+		t.Fatal("unexpected pages:", pages)
+	}
+	if pages[1].Fields[0].Name != "%a" || pages[1].Fields[0].Value != "Runs `mute {args} 10m`." {
+		t.Fatal("unexpected field:", pages[1].Fields[0])
+	}
+}
@@ -0,0 +1,150 @@
+package gommand
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DeveloperConfig is used to configure the built-in developer commands. These commands are in a hidden category which only the bot owners can use.
+type DeveloperConfig struct {
+	// Category is the category which the developer commands are added to. nil will default to a hidden "Developer" category which only the bot owners can use.
+	Category CategoryInterface
+
+	// ReloadCommands is called by the reload-commands command to reload the commands. If this is nil, the reload-commands command will not be added.
+	ReloadCommands func(ctx *Context) error
+}
+
+// Clears the cooldown if it is set. Init is called first since cooldowns outside of commands might not have been initialised.
+func clearCooldown(cooldown Cooldown) int {
+	if cooldown == nil {
+		return 0
+	}
+	cooldown.Init()
+	cooldown.Clear()
+	return 1
+}
+
+// Clears the cooldowns of the command and any sub-commands.
+func clearCommandCooldowns(cmd CommandInterface) int {
+	count := clearCooldown(cmd.GetCooldown())
+	if g, ok := cmd.(*CommandGroup); ok {
+		for _, v := range g.GetSubcommands() {
+			count += clearCommandCooldowns(v)
+		}
+	}
+	return count
+}
+
+// Creates the developer commands.
+func developerCommands(Config *DeveloperConfig) []CommandInterface {
+	cat := Config.Category
+	if cat == nil {
+		cat = &Category{
+			Name:                 "Developer",
+			Description:          "Commands which only the bot owners can use.",
+			Hidden:               true,
+			PermissionValidators: []PermissionValidator{BotOwnerOnly},
+		}
+	}
+	cmds := []CommandInterface{
+		&Command{
+			Name:        "router-stats",
+			Description: "Shows statistics about the command router.",
+			Category:    cat,
+			Function: func(ctx *Context) error {
+				var mem runtime.MemStats
+				runtime.ReadMemStats(&mem)
+				categories := 0
+				for k := range ctx.Router.GetCommandsOrderedByCategory() {
+					if k != nil {
+						categories++
+					}
+				}
+				ctx.Router.cmdLock.RLock()
+				shards := len(ctx.Router.botUsers)
+				ctx.Router.cmdLock.RUnlock()
+				uptime := ctx.Router.clock().Now().Sub(ctx.Router.started)
+				_, err := ctx.Reply(ctx.Translate(MessageRouterStats,
+					"commands", strconv.Itoa(len(ctx.Router.GetAllCommands())),
+					"categories", strconv.Itoa(categories),
+					"shards", strconv.Itoa(shards),
+					"goroutines", strconv.Itoa(runtime.NumGoroutine()),
+					"memory", strconv.FormatUint(mem.Alloc/1024/1024, 10),
+					"uptime", ctx.FormatDuration(uptime, 2)))
+				return err
+			},
+		},
+		&Command{
+			Name:        "clear-cooldowns",
+			Description: "Clears the cooldowns of a command, or every cooldown if no command is given.",
+			Usage:       "[command] [sub-command...]",
+			Category:    cat,
+			ArgTransformers: []ArgTransformer{
+				{
+					Optional:  true,
+					Remainder: true,
+					Function:  StringTransformer,
+				},
+			},
+			Function: func(ctx *Context) error {
+				path, ok := ctx.Args[0].(string)
+				if !ok {
+					// Clear every cooldown.
+					count := clearCooldown(ctx.Router.Cooldown)
+					for k, v := range ctx.Router.GetCommandsOrderedByCategory() {
+						if k != nil {
+							count += clearCooldown(k.GetCooldown())
+						}
+						for _, cmd := range v {
+							count += clearCommandCooldowns(cmd)
+						}
+					}
+					_, err := ctx.Reply(ctx.Translate(MessageCooldownsCleared, "count", strconv.Itoa(count)))
+					return err
+				}
+
+				// Get the command.
+				names := strings.Fields(path)
+				cmd := ctx.Router.GetCommand(names[0])
+				for _, name := range names[1:] {
+					g, ok := cmd.(*CommandGroup)
+					if !ok {
+						cmd = nil
+						break
+					}
+					cmd = g.GetCommand(name)
+				}
+				if cmd == nil {
+					_, err := ctx.Reply(ctx.Translate(MessageHelpNotFound, "command", strings.ToLower(path)))
+					return err
+				}
+				count := clearCommandCooldowns(cmd)
+				_, err := ctx.Reply(ctx.Translate(MessageCooldownsCleared, "count", strconv.Itoa(count)))
+				return err
+			},
+		},
+	}
+	if Config.ReloadCommands != nil {
+		cmds = append(cmds, &Command{
+			Name:        "reload-commands",
+			Description: "Reloads the commands.",
+			Category:    cat,
+			Function: func(ctx *Context) error {
+				if err := Config.ReloadCommands(ctx); err != nil {
+					return err
+				}
+				_, err := ctx.Reply(ctx.Translate(MessageCommandsReloaded))
+				return err
+			},
+		})
+	}
+	return cmds
+}
+
+// Adds the developer commands to the router.
+func addDeveloperCommands(r *Router, Config *DeveloperConfig) {
+	for _, v := range developerCommands(Config) {
+		r.setBuiltinCommand(v)
+	}
+}
@@ -0,0 +1,62 @@
+package gommand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/gommand"
+	"github.com/auttaja/gommand/gommandtest"
+)
+
+// TestDeveloperCommands is used to test the built-in developer commands.
+func TestDeveloperCommands(t *testing.T) {
+	reloads := 0
+	r, h := newTestHarness(&gommand.RouterConfig{
+		Clock:    gommandtest.NewFakeClock(time.Time{}),
+		OwnerIDs: []disgord.Snowflake{3},
+		Developer: &gommand.DeveloperConfig{
+			ReloadCommands: func(ctx *gommand.Context) error {
+				reloads++
+				return nil
+			},
+		},
+		Catalog: gommand.MapCatalog{
+			"fr": {gommand.MessageRouterStats: "**Statistiques**\nCommandes : {commands}"},
+		},
+		LocaleResolver: func(ctx *gommand.Context) string {
+			if ctx.Message.ChannelID == 31 {
+				return "fr"
+			}
+			return ""
+		},
+	})
+	ping := replyCommand("ping", "Pong!")
+	ping.Cooldown = &gommand.UserCooldown{MaxRuns: 1, UsageExpires: time.Minute}
+	r.SetCommand(ping)
+	owner := h.Conversation(t, 31, 3)
+	user := h.Conversation(t, 30, 4)
+
+	// Only the owners can use the commands.
+	user.Send("%reload-commands")
+	user.Expect("This command can only be used by the bot owners.")
+	owner.Send("%reload-commands")
+	owner.Expect("Reloaded the commands.")
+	if reloads != 1 {
+		t.Fatal("the commands were not reloaded")
+	}
+
+	// Clearing the cooldowns allows the command to be used again.
+	user.Send("%ping")
+	user.Expect("Pong!")
+	user.Send("%ping")
+	user.Expect("This command has a 1 minute cooldown.")
+	owner.Send("%clear-cooldowns ping")
+	owner.Expect("Cleared 1 cooldown(s).")
+	user.Send("%ping")
+	user.Expect("Pong!")
+
+	// Check the stats are translated.
+	owner.Send("%router-stats")
+	owner.Expect("Commandes : 5")
+}
@@ -72,7 +72,7 @@ func permissionsWrapper(PermissionName string, PermissionsHex disgord.Permission
 					return "", true
 				}
 
-				return "You must have the  \"" + PermissionName + "\" permission to run this command.", perms.Contains(PermissionsHex)
+				return ctx.Translate(MessageMemberPermissionMissing, "permission", PermissionName), perms.Contains(PermissionsHex)
 			}
 			checks = append(checks, f)
 		}
@@ -107,7 +107,7 @@ func permissionsWrapper(PermissionName string, PermissionsHex disgord.Permission
 					return "", true
 				}
 
-				return "The bot must have the  \"" + PermissionName + "\" permission to run this command.", perms.Contains(PermissionsHex)
+				return ctx.Translate(MessageBotPermissionMissing, "permission", PermissionName), perms.Contains(PermissionsHex)
 			}
 			checks = append(checks, f)
 		}
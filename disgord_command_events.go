@@ -3,6 +3,7 @@ package gommand
 import (
 	"github.com/andersfylling/disgord"
 	"io"
+	"io/ioutil"
 	"strings"
 )
 
@@ -37,14 +38,17 @@ func (r *Router) CommandProcessor(s disgord.Session, ShardID uint, msg *disgord.
 		return
 	}
 
-	// Read lock the commands.
+	// Get the commands and the bot user. The command table is never modified once the router uses it, so the lock is not held while the message is processed.
 	r.cmdLock.RLock()
+	cmds := r.cmds
+	botUser := r.botUsers[ShardID]
+	r.cmdLock.RUnlock()
 
 	// Create the context.
 	ctx := &Context{
 		ShardID:          ShardID,
 		Message:          msg,
-		BotUser:          r.botUsers[ShardID],
+		BotUser:          botUser,
 		Router:           r,
 		Session:          s,
 		Args:             []interface{}{},
@@ -52,10 +56,22 @@ func (r *Router) CommandProcessor(s disgord.Session, ShardID uint, msg *disgord.
 	}
 	ctx.WaitManager = &WaitManager{ctx: ctx}
 
+	// Start the trace for the message.
+	root := r.tracer().StartSpan(nil, "message")
+	root.SetAttribute("guild_id", msg.GuildID.String())
+	root.SetAttribute("channel_id", msg.ChannelID.String())
+	root.SetAttribute("message_id", msg.ID.String())
+	root.SetAttribute("shard_id", ShardID)
+	ctx.span = root
+	defer root.End()
+
 	// Create a read seeker of the message content.
 	reader := strings.NewReader(msg.Content)
 	if r.GetState != nil {
-		if err := r.GetState(ctx); err != nil {
+		err := ctx.traceFunc("get_state", func(Span) error {
+			return r.GetState(ctx)
+		})
+		if err != nil {
 			r.errorHandler(ctx, err)
 			return
 		}
@@ -63,17 +79,40 @@ func (r *Router) CommandProcessor(s disgord.Session, ShardID uint, msg *disgord.
 
 	// Run a prefix check.
 	if prefix {
-		if !r.PrefixCheck(ctx, reader) {
+		span := ctx.StartSpan("prefix_check")
+		matched := r.PrefixCheck(ctx, reader)
+		span.SetAttribute("matched", matched)
+		span.End()
+		if !matched {
 			// The prefix was not used.
-			r.cmdLock.RUnlock()
 			return
 		}
+		r.hooks().PrefixMatched(ctx)
 	}
 
 	// Parts of the member should be patched into the message object here to make it easier to use.
 	msg.Member.GuildID = msg.GuildID
 	msg.Member.User = msg.Author
 
+	// If chaining is enabled, split the message into the commands it runs.
+	if r.chaining != nil && prefix {
+		rest, _ := ioutil.ReadAll(reader)
+		parts := splitChain(ctx, string(rest))
+		if len(parts) > 1 {
+			r.runChain(ctx, cmds, parts)
+			return
+		}
+		reader = strings.NewReader(string(rest))
+	}
+
+	// Run the invocation.
+	if err := r.runInvocation(ctx, cmds, reader); err != nil {
+		r.errorHandler(ctx, err)
+	}
+}
+
+// Runs the command invocation in the reader, which should be after the prefix, using the command table given.
+func (r *Router) runInvocation(ctx *Context, cmds *commandTable, reader *strings.Reader) error {
 	// Iterate the message until the space.
 	cmdname := ""
 	for {
@@ -84,9 +123,28 @@ func (r *Router) CommandProcessor(s disgord.Session, ShardID uint, msg *disgord.
 		cmdname += string(b)
 	}
 	if cmdname == "" {
-		r.cmdLock.RUnlock()
-		r.errorHandler(ctx, &CommandBlank{err: "The command is blank."})
-		return
+		return &CommandBlank{err: ctx.Translate(MessageCommandBlank)}
+	}
+
+	// Get the command if it exists. If it doesn't, check if this is a guild shortcut, and if it is, replace it with the command and arguments it runs.
+	cmd := cmds.get(ctx.Locale(), cmdname)
+	if cmd == nil && r.ShortcutStorageAdapter != nil {
+		shortcut, err := r.ShortcutStorageAdapter.Get(ctx.Message.GuildID, strings.ToLower(cmdname))
+		if err != nil {
+			return err
+		}
+		if shortcut != nil {
+			rest, _ := ioutil.ReadAll(reader)
+			expanded := strings.SplitN(shortcut.Expand(string(rest)), " ", 2)
+			cmdname = expanded[0]
+			if len(expanded) == 2 {
+				reader = strings.NewReader(expanded[1])
+			} else {
+				reader = strings.NewReader("")
+			}
+			ctx.Shortcut = shortcut
+			cmd = cmds.get(ctx.Locale(), cmdname)
+		}
 	}
 
 	// Get the remainder as raw arguments.
@@ -96,11 +154,9 @@ func (r *Router) CommandProcessor(s disgord.Session, ShardID uint, msg *disgord.
 	_, _ = reader.Seek(int64(len(remainder)*-1), io.SeekCurrent)
 	ctx.RawArgs = remainder
 
-	// Get the command if it exists.
-	cmd := r.cmds[strings.ToLower(cmdname)]
+	// If the command doesn't exist, pass it to the custom commands handler.
 	ctx.Command = cmd
 	if cmd == nil {
-		r.cmdLock.RUnlock()
 		var ok bool
 		var err error
 		if r.CustomCommandsHandler != nil {
@@ -108,22 +164,16 @@ func (r *Router) CommandProcessor(s disgord.Session, ShardID uint, msg *disgord.
 			ok, err = r.CustomCommandsHandler(ctx, cmdname, parser)
 			parser.Done()
 		}
-		if err == nil {
-			if !ok {
-				r.errorHandler(ctx, &CommandBlank{err: "The command \"" + cmdname + "\" does not exist."})
-			}
-		} else {
-			r.errorHandler(ctx, err)
+		if err == nil && !ok {
+			err = &CommandBlank{err: ctx.Translate(MessageCommandNotExists, "command", cmdname)}
 		}
-		return
+		return err
 	}
 
 	// Run the command handler.
-	r.cmdLock.RUnlock()
-	err := runCommand(ctx, reader, cmd)
-	if err != nil {
-		r.errorHandler(ctx, err)
-	}
+	ctx.span.SetAttribute("command", cmd.GetName())
+	r.hooks().CommandResolved(ctx, cmd)
+	return runCommand(ctx, reader, cmd)
 }
 
 // Handles processing new messages.
@@ -149,6 +199,6 @@ func (r *Router) Hook(s disgord.Session) {
 		gateway.MessageDeleteBulk(r.MessageCacheHandler.bulkDeleteHandler)
 	}
 
-	gateway.MessageReactionAdd(handleMenuReactionEdit)
-	gateway.MessageDelete(handleEmbedMenuMessageDelete)
+	gateway.MessageReactionAdd(r.menuReactionAdd)
+	gateway.MessageDelete(r.menuMessageDelete)
 }
@@ -52,7 +52,17 @@ type EmbedMenu struct {
 	Embed     *disgord.Embed
 	MenuInfo  *MenuInfo
 
-	myID disgord.Snowflake
+	// MenuID is the ID of a menu builder registered with Router.RegisterMenu.
+	// If this is set and the router has a MenuStorageAdapter, the menu will be persisted so it works after a restart.
+	MenuID string
+
+	// MenuState is the serialized state which is passed to the menu builder when the menu is rebuilt.
+	MenuState []byte
+
+	myID     disgord.Snowflake
+	router   *Router
+	locale   string
+	children []*EmbedMenu
 }
 
 // Add is used to add a menu reaction.
@@ -77,6 +87,9 @@ func (e *EmbedMenu) Display(ChannelID, MessageID disgord.Snowflake, client disgo
 		menuCache[MessageID] = e
 	}
 	menuCacheLock.Unlock()
+	if e.router != nil {
+		e.router.persistMenu(MessageID, ChannelID, e)
+	}
 
 	EmbedCopy := disgord.DeepCopy(e.Embed).(*disgord.Embed)
 	Fields := make([]*disgord.EmbedField, 0)
@@ -129,11 +142,16 @@ func (e *EmbedMenu) NewChildMenu(options *ChildMenuOptions) *EmbedMenu {
 		Reactions: &MenuReactions{
 			ReactionSlice: []MenuReaction{},
 		},
-		Embed:    options.Embed,
-		MenuInfo: e.MenuInfo,
-		myID:     e.myID,
+		Embed:     options.Embed,
+		MenuInfo:  e.MenuInfo,
+		MenuID:    e.MenuID,
+		MenuState: e.MenuState,
+		myID:      e.myID,
+		router:    e.router,
+		locale:    e.locale,
 	}
 	NewEmbedMenu.parent = e
+	e.children = append(e.children, NewEmbedMenu)
 	Reaction := MenuReaction{
 		Button: options.Button,
 		Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
@@ -154,8 +172,8 @@ func (e *EmbedMenu) NewChildMenu(options *ChildMenuOptions) *EmbedMenu {
 func (e *EmbedMenu) AddBackButton() {
 	Reaction := MenuReaction{
 		Button: &MenuButton{
-			Description: "Goes back to the parent menu.",
-			Name:        "Back",
+			Description: e.router.Translate(e.locale, MessageMenuBackDescription),
+			Name:        e.router.Translate(e.locale, MessageMenuBack),
 			Emoji:       "⬆",
 		},
 		Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
@@ -169,8 +187,8 @@ func (e *EmbedMenu) AddBackButton() {
 func (e *EmbedMenu) AddExitButton() {
 	Reaction := MenuReaction{
 		Button: &MenuButton{
-			Description: "Exits the current menu.",
-			Name:        "Exit",
+			Description: e.router.Translate(e.locale, MessageMenuExitDescription),
+			Name:        e.router.Translate(e.locale, MessageMenuExit),
 			Emoji:       "❌",
 		},
 		Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
@@ -184,7 +202,9 @@ func (e *EmbedMenu) AddExitButton() {
 func NewEmbedMenu(embed *disgord.Embed, ctx *Context) *EmbedMenu {
 	var reactions []MenuReaction
 	menu := &EmbedMenu{
-		myID: ctx.BotUser.ID,
+		myID:   ctx.BotUser.ID,
+		router: ctx.Router,
+		locale: ctx.Locale(),
 		Reactions: &MenuReactions{
 			ReactionSlice: reactions,
 		},
@@ -214,9 +234,39 @@ type EmbedLifetimeOptions struct {
 	// Called regardless of errors returned when deleting the message.
 	AfterDelete func()
 
-	maxLifetimeTimer *time.Timer
+	maxLifetimeTimer Timer
 
-	inactiveTimer *time.Timer
+	inactiveTimer Timer
+
+	clock Clock
+
+	router *Router
+}
+
+// Deletes the menu once its lifetime has been exceeded.
+func (l *EmbedLifetimeOptions) expire(ChannelID, MessageID disgord.Snowflake, client disgord.Session) {
+	if l.BeforeDelete != nil {
+		l.BeforeDelete()
+	}
+	err := client.Channel(ChannelID).Message(MessageID).Delete()
+	if err != nil {
+		// If there was an error deleting the message, remove from the menu cache anyway.
+		menuCacheLock.Lock()
+		delete(menuCache, MessageID)
+		menuCacheLock.Unlock()
+
+		menuLifetimeCacheLock.Lock()
+		delete(menuLifetimeCache, MessageID)
+		menuLifetimeCacheLock.Unlock()
+	}
+
+	// The menu can no longer be used, so remove it from the storage adapter.
+	if l.router != nil && l.router.MenuStorageAdapter != nil {
+		l.router.MenuStorageAdapter.Delete(MessageID)
+	}
+	if l.AfterDelete != nil {
+		l.AfterDelete()
+	}
 }
 
 // Start inits the timers for the lifetime.
@@ -225,50 +275,22 @@ func (l *EmbedLifetimeOptions) Start(ChannelID, MessageID disgord.Snowflake, cli
 		// This is blank, don't bother caching / updating it.
 		return
 	}
+	clock := l.clock
+	if clock == nil {
+		clock = SystemClock
+	}
 
 	menuLifetimeCacheLock.Lock()
 	if l.MaximumLifetime > time.Duration(0) {
 		// init the maxLifetimeTimer if the MaximumLifetime is a positive non-zero value.
-		l.maxLifetimeTimer = time.AfterFunc(l.MaximumLifetime, func() {
-			if l.BeforeDelete != nil {
-				l.BeforeDelete()
-			}
-			err := client.Channel(ChannelID).Message(MessageID).Delete()
-			if err != nil {
-				// If there was an error deleting the message, remove from the menu cache anyway.
-				menuCacheLock.Lock()
-				delete(menuCache, MessageID)
-				menuCacheLock.Unlock()
-
-				menuLifetimeCacheLock.Lock()
-				delete(menuLifetimeCache, MessageID)
-				menuLifetimeCacheLock.Unlock()
-			}
-			if l.AfterDelete != nil {
-				l.AfterDelete()
-			}
+		l.maxLifetimeTimer = clock.AfterFunc(l.MaximumLifetime, func() {
+			l.expire(ChannelID, MessageID, client)
 		})
 	}
 
 	if l.InactiveLifetime > time.Duration(0) {
-		l.inactiveTimer = time.AfterFunc(l.InactiveLifetime, func() {
-			if l.BeforeDelete != nil {
-				l.BeforeDelete()
-			}
-			err := client.Channel(ChannelID).Message(MessageID).Delete()
-			if err != nil {
-				// If there was an error deleting the message, remove from the menu cache anyway.
-				menuCacheLock.Lock()
-				delete(menuCache, MessageID)
-				menuCacheLock.Unlock()
-
-				menuLifetimeCacheLock.Lock()
-				delete(menuLifetimeCache, MessageID)
-				menuLifetimeCacheLock.Unlock()
-			}
-			if l.AfterDelete != nil {
-				l.AfterDelete()
-			}
+		l.inactiveTimer = clock.AfterFunc(l.InactiveLifetime, func() {
+			l.expire(ChannelID, MessageID, client)
 		})
 	}
 
@@ -276,53 +298,42 @@ func (l *EmbedLifetimeOptions) Start(ChannelID, MessageID disgord.Snowflake, cli
 	menuLifetimeCacheLock.Unlock()
 }
 
-// This is used to handle menu reactions.
-func handleMenuReactionEdit(s disgord.Session, evt *disgord.MessageReactionAdd) {
-	go func() {
-		// Get the menu if it exists.
-		menuCacheLock.RLock()
-		menu := menuCache[evt.MessageID]
-		if menu == nil {
-			menuCacheLock.RUnlock()
-			return
-		}
-		menuCacheLock.RUnlock()
+// This is used to handle a reaction on a menu.
+func handleMenuReaction(s disgord.Session, evt *disgord.MessageReactionAdd, menu *EmbedMenu) {
+	// Remove the reaction.
+	if evt.UserID == menu.myID {
+		// This is by me! Do not delete!
+		return
+	}
+	_ = s.Channel(evt.ChannelID).Message(evt.MessageID).Reaction(evt.PartialEmoji).DeleteUser(evt.UserID)
 
-		// Remove the reaction.
-		if evt.UserID == menu.myID {
-			// This is by me! Do not delete!
-			return
-		}
-		_ = s.Channel(evt.ChannelID).Message(evt.MessageID).Reaction(evt.PartialEmoji).DeleteUser(evt.UserID)
+	// Check the author of the reaction.
+	if menu.MenuInfo.Author != evt.UserID.String() {
+		return
+	}
 
-		// Check the author of the reaction.
-		if menu.MenuInfo.Author != evt.UserID.String() {
-			return
+	for _, v := range menu.Reactions.ReactionSlice {
+		standardized := ""
+		if evt.PartialEmoji.ID == 0 {
+			standardized = evt.PartialEmoji.Name
+		} else {
+			standardized = evt.PartialEmoji.Name + ":" + evt.PartialEmoji.ID.String()
 		}
 
-		for _, v := range menu.Reactions.ReactionSlice {
-			standardized := ""
-			if evt.PartialEmoji.ID == 0 {
-				standardized = evt.PartialEmoji.Name
-			} else {
-				standardized = evt.PartialEmoji.Name + ":" + evt.PartialEmoji.ID.String()
-			}
-
-			// We use HasSuffix here because of the "a:" that might be attached.
-			if strings.HasSuffix(v.Button.Emoji, standardized) {
-				menuLifetimeCacheLock.Lock()
-				if lifetime, ok := menuLifetimeCache[evt.MessageID]; ok && lifetime.inactiveTimer != nil {
-					if lifetime.inactiveTimer.Stop() {
-						// Only reset the timer if it's still "active".
-						_ = lifetime.inactiveTimer.Reset(lifetime.InactiveLifetime)
-					}
+		// We use HasSuffix here because of the "a:" that might be attached.
+		if strings.HasSuffix(v.Button.Emoji, standardized) {
+			menuLifetimeCacheLock.Lock()
+			if lifetime, ok := menuLifetimeCache[evt.MessageID]; ok && lifetime.inactiveTimer != nil {
+				if lifetime.inactiveTimer.Stop() {
+					// Only reset the timer if it's still "active".
+					_ = lifetime.inactiveTimer.Reset(lifetime.InactiveLifetime)
 				}
-				menuLifetimeCacheLock.Unlock()
-				v.Function(evt.ChannelID, evt.MessageID, menu, s)
-				return
 			}
+			menuLifetimeCacheLock.Unlock()
+			v.Function(evt.ChannelID, evt.MessageID, menu, s)
+			return
 		}
-	}()
+	}
 }
 
 // Handle messages being deleted to stop memory leaks.
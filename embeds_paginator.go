@@ -8,23 +8,31 @@ import (
 	"github.com/andersfylling/disgord"
 )
 
-// EmbedsPaginatorWithLifetime is used to paginate together several embeds, but with an optional *EmbedLifetimeOptions parameter to control the embed lifetime.
-// Passing nil to this parameter indicates no maximum lifetime.
-func EmbedsPaginatorWithLifetime(ctx *Context, Pages []*disgord.Embed, InitialPage uint, NoButtonTextContent string, Lifetime *EmbedLifetimeOptions) (err error) {
-	// Check the permissions which the bot has permission to use embed menus in this channel.
+// Checks if the bot has the permissions required to use embed menus in the channel.
+func canUseEmbedMenus(ctx *Context) (bool, error) {
 	c, err := ctx.Channel()
 	if err != nil {
-		return err
+		return false, err
 	}
 	m, err := ctx.BotMember()
 	if err != nil {
-		return err
+		return false, err
 	}
 	perms, err := c.GetPermissions(context.TODO(), ctx.Session, m)
+	if err != nil {
+		return false, err
+	}
+	return (perms&disgord.PermissionAdministrator) == disgord.PermissionAdministrator || ((perms&disgord.PermissionManageMessages) == disgord.PermissionManageMessages && (perms&disgord.PermissionAddReactions) == disgord.PermissionAddReactions), nil
+}
+
+// EmbedsPaginatorWithLifetime is used to paginate together several embeds, but with an optional *EmbedLifetimeOptions parameter to control the embed lifetime.
+// Passing nil to this parameter indicates no maximum lifetime.
+func EmbedsPaginatorWithLifetime(ctx *Context, Pages []*disgord.Embed, InitialPage uint, NoButtonTextContent string, Lifetime *EmbedLifetimeOptions) (err error) {
+	// Check the permissions which the bot has permission to use embed menus in this channel.
+	UseEmbedMenus, err := canUseEmbedMenus(ctx)
 	if err != nil {
 		return err
 	}
-	UseEmbedMenus := (perms&disgord.PermissionAdministrator) == disgord.PermissionAdministrator || ((perms&disgord.PermissionManageMessages) == disgord.PermissionManageMessages && (perms&disgord.PermissionAddReactions) == disgord.PermissionAddReactions)
 
 	// Get the pages length.
 	PagesLen := len(Pages)
@@ -46,7 +54,7 @@ func EmbedsPaginatorWithLifetime(ctx *Context, Pages []*disgord.Embed, InitialPa
 	PrepareEmbed := func(em *disgord.Embed) *disgord.Embed {
 		em = disgord.DeepCopy(em).(*disgord.Embed)
 		em.Footer = &disgord.EmbedFooter{
-			Text: "Page " + strconv.Itoa(CurrentPage) + "/" + strconv.Itoa(PagesLen),
+			Text: ctx.Translate(MessagePageFooter, "page", strconv.Itoa(CurrentPage), "pages", strconv.Itoa(PagesLen)),
 		}
 		return em
 	}
@@ -71,15 +79,15 @@ func EmbedsPaginatorWithLifetime(ctx *Context, Pages []*disgord.Embed, InitialPa
 					Embed: em,
 					Button: &MenuButton{
 						Emoji:       "▶️",
-						Name:        "Forward",
-						Description: "Goes forward a page.",
+						Name:        ctx.Translate(MessagePageForward),
+						Description: ctx.Translate(MessagePageForwardDescription),
 					},
 				})
 				LastPage.Reactions.Add(MenuReaction{
 					Button: &MenuButton{
 						Emoji:       "◀️",
-						Name:        "Back",
-						Description: "Goes back a page.",
+						Name:        ctx.Translate(MessagePageBack),
+						Description: ctx.Translate(MessagePageBackDescription),
 					},
 					Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
 						_ = PageBefore.Display(ChannelID, MessageID, client)
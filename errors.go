@@ -1,5 +1,7 @@
 package gommand
 
+import "fmt"
+
 // CommandNotFound is the error which is thrown when a command is not found.
 type CommandNotFound struct {
 	err string
@@ -60,12 +62,116 @@ func (c *InvalidTransformation) Error() string {
 	return c.Description
 }
 
-// PanicError is used when a string is returned from a panic. If it isn't a string, the error will just be pushed into the handler.
+// PanicError is the error which is passed to the error handlers when a command panics.
 type PanicError struct {
 	msg string
+
+	// Value is the value which was passed to panic.
+	Value interface{}
+
+	// Stack is the stack trace of the goroutine which panicked.
+	Stack []byte
+}
+
+// Creates a panic error from the recovered value.
+func newPanicError(Value interface{}, Stack []byte) *PanicError {
+	var msg string
+	switch v := Value.(type) {
+	case string:
+		msg = v
+	case error:
+		msg = v.Error()
+	default:
+		msg = fmt.Sprint(v)
+	}
+	return &PanicError{msg: msg, Value: Value, Stack: Stack}
 }
 
 // Error is used to give the error description.
 func (c *PanicError) Error() string {
 	return c.msg
 }
+
+// Unwrap is used to get the error which was passed to panic. This is nil if the value was not an error.
+func (c *PanicError) Unwrap() error {
+	err, _ := c.Value.(error)
+	return err
+}
+
+// PromptCancelled is the error which is returned when the user cancels a prompt.
+type PromptCancelled struct {
+	err string
+}
+
+// Error is used to give the error description.
+func (c *PromptCancelled) Error() string {
+	return c.err
+}
+
+// PromptTimeout is the error which is returned when the user doesn't answer a prompt in time.
+type PromptTimeout struct {
+	err string
+}
+
+// Error is used to give the error description.
+func (c *PromptTimeout) Error() string {
+	return c.err
+}
+
+// CommandConflict is the error which is returned when a name or alias of a command is already used by a different command.
+type CommandConflict struct {
+	// Name is the name or alias which is already used.
+	Name string
+
+	// Locale is the locale of the name if it is a localised name. This is blank otherwise.
+	Locale string
+
+	// Command is the command which was being added.
+	Command CommandInterface
+
+	// Existing is the command which already uses the name.
+	Existing CommandInterface
+}
+
+// Error is used to give the error description.
+func (c *CommandConflict) Error() string {
+	msg := "the name \"" + c.Name + "\" of the command \"" + c.Command.GetName() + "\" is already used by the command \"" + c.Existing.GetName() + "\""
+	if c.Locale != "" {
+		msg += " in the locale \"" + c.Locale + "\""
+	}
+	return msg
+}
+
+// InvalidArgTransformers is the error which is returned when the argument transformers of a command are in an invalid order.
+type InvalidArgTransformers struct {
+	// Path is the path of the command, including any command groups.
+	Path string
+
+	// Problem is the description of the problem.
+	Problem string
+}
+
+// Error is used to give the error description.
+func (c *InvalidArgTransformers) Error() string {
+	return "the arguments of the command \"" + c.Path + "\" are invalid: " + c.Problem
+}
+
+// ChainTooLong is the error which is thrown when a message chains more commands than the router allows.
+type ChainTooLong struct {
+	err string
+}
+
+// Error is used to give the error description.
+func (c *ChainTooLong) Error() string {
+	return c.err
+}
+
+// CommandNotPipeable is the error which is thrown when a command is piped from or into but does not produce or consume text.
+type CommandNotPipeable struct {
+	err string
+}
+
+// Error is used to give the error description.
+func (c *CommandNotPipeable) Error() string {
+	return c.err
+}
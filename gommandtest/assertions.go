@@ -0,0 +1,71 @@
+package gommandtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Checks if the content or the title/description of an embed in the message contains the text.
+func messageContains(msg *disgord.Message, Text string) bool {
+	if strings.Contains(msg.Content, Text) {
+		return true
+	}
+	for _, v := range msg.Embeds {
+		if strings.Contains(v.Title, Text) || strings.Contains(v.Description, Text) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertMessageCount is used to assert the number of messages the bot has in a channel.
+func AssertMessageCount(t testing.TB, s *Session, ChannelID disgord.Snowflake, Count int) {
+	t.Helper()
+	if msgs := s.SentMessages(ChannelID); len(msgs) != Count {
+		t.Fatalf("expected %d messages from the bot in channel %s, got %d", Count, ChannelID, len(msgs))
+	}
+}
+
+// AssertLastMessageContains is used to assert that the last message the bot sent in a channel contains the text.
+// Both the message content and the title/description of the embed are checked. The message is returned.
+func AssertLastMessageContains(t testing.TB, s *Session, ChannelID disgord.Snowflake, Text string) *disgord.Message {
+	t.Helper()
+	msg := s.LastMessage(ChannelID)
+	if msg == nil {
+		t.Fatalf("expected a message from the bot in channel %s, got none", ChannelID)
+		return nil
+	}
+	if messageContains(msg, Text) {
+		return msg
+	}
+	t.Fatalf("expected the last message from the bot to contain %q, got %q", Text, msg.Content)
+	return nil
+}
+
+// AssertEmbed is used to assert that the message has an embed and that the check function returns true for it.
+func AssertEmbed(t testing.TB, Message *disgord.Message, CheckFunc func(embed *disgord.Embed) bool) {
+	t.Helper()
+	if Message == nil || len(Message.Embeds) == 0 {
+		t.Fatal("expected the message to have an embed")
+		return
+	}
+	if !CheckFunc(Message.Embeds[0]) {
+		t.Fatalf("the embed did not pass the check: %+v", Message.Embeds[0])
+	}
+}
+
+// AssertReactions is used to assert the reactions the bot has added to a message, in order.
+func AssertReactions(t testing.TB, s *Session, MessageID disgord.Snowflake, Emojis ...string) {
+	t.Helper()
+	reactions := make([]string, 0)
+	for _, v := range s.Reactions(MessageID) {
+		if v.UserID == s.BotUser.ID {
+			reactions = append(reactions, v.Emoji)
+		}
+	}
+	if strings.Join(reactions, " ") != strings.Join(Emojis, " ") {
+		t.Fatalf("expected the reactions %v, got %v", Emojis, reactions)
+	}
+}
@@ -0,0 +1,122 @@
+package gommandtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/auttaja/gommand"
+)
+
+// FakeClock is a gommand.Clock which only moves forward when Advance is called.
+// Pass this as the Clock in the RouterConfig to control cooldowns and menu lifetimes in tests.
+type FakeClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock is used to create a fake clock starting at the time specified. If the time is zero, the current time is used.
+func NewFakeClock(Start time.Time) *FakeClock {
+	if Start.IsZero() {
+		Start = time.Now()
+	}
+	return &FakeClock{now: Start}
+}
+
+// Now is used to get the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// AfterFunc is used to call the function once the clock has been advanced by the duration.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) gommand.Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	t := &fakeTimer{c: c, f: f}
+	t.schedule(d)
+	return t
+}
+
+// Advance is used to move the clock forward, calling the functions of any timers which expire in the order they expire.
+// The functions are called synchronously, so anything they do has finished when this returns.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	target := c.now.Add(d)
+	for {
+		var next *fakeTimer
+		for _, v := range c.timers {
+			if !v.when.After(target) && (next == nil || v.when.Before(next.when)) {
+				next = v
+			}
+		}
+		if next == nil {
+			break
+		}
+		next.unschedule()
+		if next.when.After(c.now) {
+			c.now = next.when
+		}
+		c.lock.Unlock()
+		next.f()
+		c.lock.Lock()
+	}
+	c.now = target
+	c.lock.Unlock()
+}
+
+// Pending is used to get the number of timers which have not expired or been stopped.
+func (c *FakeClock) Pending() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.timers)
+}
+
+// Defines a timer created by the fake clock.
+type fakeTimer struct {
+	c      *FakeClock
+	when   time.Time
+	f      func()
+	active bool
+}
+
+// Schedules the timer. The clock lock must be held.
+func (t *fakeTimer) schedule(d time.Duration) {
+	t.when = t.c.now.Add(d)
+	if !t.active {
+		t.active = true
+		t.c.timers = append(t.c.timers, t)
+	}
+}
+
+// Removes the timer from the clock. The clock lock must be held.
+func (t *fakeTimer) unschedule() bool {
+	if !t.active {
+		return false
+	}
+	t.active = false
+	for i, v := range t.c.timers {
+		if v == t {
+			t.c.timers = append(t.c.timers[:i:i], t.c.timers[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Stop is used to stop the timer.
+func (t *fakeTimer) Stop() bool {
+	t.c.lock.Lock()
+	defer t.c.lock.Unlock()
+	return t.unschedule()
+}
+
+// Reset is used to change the timer to expire after the duration specified.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.c.lock.Lock()
+	defer t.c.lock.Unlock()
+	active := t.active
+	t.schedule(d)
+	return active
+}
@@ -0,0 +1,204 @@
+package gommandtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Conversation is used to script a conversation between a user and the bot in a channel.
+// Unlike SendMessage on the harness, messages are dispatched through the gateway like Discord would, so commands which wait for messages or reactions can be tested.
+type Conversation struct {
+	t    testing.TB
+	h    *Harness
+	seen map[disgord.Snowflake]bool
+
+	// ChannelID is the ID of the channel the conversation is in.
+	ChannelID disgord.Snowflake
+
+	// UserID is the ID of the user talking to the bot.
+	UserID disgord.Snowflake
+
+	// Timeout is how long to wait for the bot before failing the test. This defaults to 1 second.
+	Timeout time.Duration
+}
+
+// Conversation is used to start a scripted conversation with the bot from the user in the channel.
+func (h *Harness) Conversation(t testing.TB, ChannelID, UserID disgord.Snowflake) *Conversation {
+	return &Conversation{
+		t:         t,
+		h:         h,
+		seen:      map[disgord.Snowflake]bool{},
+		ChannelID: ChannelID,
+		UserID:    UserID,
+		Timeout:   time.Second,
+	}
+}
+
+// Waits until the function returns true, failing the test if the timeout is reached.
+func (c *Conversation) waitUntil(what string, f func() bool) {
+	c.t.Helper()
+	deadline := time.Now().Add(c.Timeout)
+	for !f() {
+		if time.Now().After(deadline) {
+			c.t.Fatal("timed out waiting for " + what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Checks if the bot is waiting for an event within a command.
+func (c *Conversation) waiting(EventName string) bool {
+	return c.h.Session.HandlerCount(EventName) > c.h.baseHandlers[EventName]
+}
+
+// Send is used to send a message from the user. The message is dispatched as a message create event straight away.
+func (c *Conversation) Send(Content string) *disgord.Message {
+	msg := c.h.Message(c.ChannelID, c.UserID, Content)
+	c.h.Session.Dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{
+		Message: disgord.DeepCopy(msg).(*disgord.Message),
+		ShardID: c.h.ShardID,
+	})
+	return msg
+}
+
+// Answer is used to wait until the bot is waiting for a message and then send a message from the user.
+// Use this rather than Send when answering a question asked by a command.
+func (c *Conversation) Answer(Content string) *disgord.Message {
+	c.t.Helper()
+	c.waitUntil("the bot to wait for a message", func() bool {
+		return c.waiting(disgord.EvtMessageCreate)
+	})
+	return c.Send(Content)
+}
+
+// React is used to wait until the bot has added the emoji to the message and then react with it as the user.
+// Use this to click embed menu buttons.
+func (c *Conversation) React(MessageID disgord.Snowflake, Emoji string) {
+	c.t.Helper()
+	c.waitUntil("the bot to react with "+Emoji, func() bool {
+		for _, v := range c.h.Session.Reactions(MessageID) {
+			if v.UserID == c.h.Session.BotUser.ID && v.Emoji == Emoji {
+				return true
+			}
+		}
+		return false
+	})
+	c.h.Session.AddReaction(c.ChannelID, MessageID, c.UserID, Emoji)
+}
+
+// AnswerReaction is used to wait until the bot has added the emoji to the message and is waiting for a reaction, and then react with it as the user.
+// Use this rather than React when answering a question asked by a command.
+func (c *Conversation) AnswerReaction(MessageID disgord.Snowflake, Emoji string) {
+	c.t.Helper()
+	c.waitUntil("the bot to wait for a reaction", func() bool {
+		return c.waiting(disgord.EvtMessageReactionAdd)
+	})
+	c.React(MessageID, Emoji)
+}
+
+// AwaitTimers is used to wait until the fake clock has at least the number of timers specified pending.
+// This is useful before calling Advance, since some timers are started after the bot has replied.
+func (c *Conversation) AwaitTimers(Count int) {
+	c.t.Helper()
+	if c.h.Clock == nil {
+		c.t.Fatal("the router is not using a FakeClock")
+	}
+	c.waitUntil("the timers to be started", func() bool {
+		return c.h.Clock.Pending() >= Count
+	})
+}
+
+// Advance is used to move the fake clock of the router forward. Any timers which expire are ran before this returns.
+func (c *Conversation) Advance(d time.Duration) {
+	c.t.Helper()
+	if c.h.Clock == nil {
+		c.t.Fatal("the router is not using a FakeClock")
+	}
+	c.h.Clock.Advance(d)
+}
+
+// Expect is used to wait for the next message from the bot which has not already been expected, and check it contains the text.
+// Since menus are edited after being sent, the message is checked until it contains the text or the timeout is reached.
+func (c *Conversation) Expect(Text string) *disgord.Message {
+	c.t.Helper()
+	var msg *disgord.Message
+	c.waitUntil("a message from the bot containing "+Text, func() bool {
+		if msg == nil {
+			for _, v := range c.h.Session.SentMessages(c.ChannelID) {
+				if !c.seen[v.ID] {
+					msg = v
+					break
+				}
+			}
+			if msg == nil {
+				return false
+			}
+		} else if msg = c.h.Session.Message(c.ChannelID, msg.ID); msg == nil {
+			return false
+		}
+		return messageContains(msg, Text)
+	})
+	c.seen[msg.ID] = true
+	return msg
+}
+
+// ExpectEdit is used to wait until the message contains the text.
+func (c *Conversation) ExpectEdit(MessageID disgord.Snowflake, Text string) *disgord.Message {
+	c.t.Helper()
+	var msg *disgord.Message
+	c.waitUntil("the message to be edited to contain "+Text, func() bool {
+		msg = c.h.Session.Message(c.ChannelID, MessageID)
+		return msg != nil && messageContains(msg, Text)
+	})
+	return msg
+}
+
+// ExpectDeleted is used to wait until the message is deleted.
+func (c *Conversation) ExpectDeleted(MessageID disgord.Snowflake) {
+	c.t.Helper()
+	c.waitUntil("the message to be deleted", func() bool {
+		return c.h.Session.Deleted(MessageID)
+	})
+}
+
+// Transcript is used to get the messages in the channel which have not been deleted, with one line per message.
+// Each line is the username of the author, a colon and the content. Embeds are shown as their title in square brackets.
+func (c *Conversation) Transcript() []string {
+	msgs := c.h.Session.Messages(c.ChannelID)
+	lines := make([]string, len(msgs))
+	for i, v := range msgs {
+		parts := make([]string, 0, 1+len(v.Embeds))
+		if v.Content != "" {
+			parts = append(parts, v.Content)
+		}
+		for _, e := range v.Embeds {
+			parts = append(parts, "["+e.Title+"]")
+		}
+		name := "unknown"
+		if v.Author != nil {
+			name = v.Author.Username
+		}
+		lines[i] = name + ": " + strings.Join(parts, " ")
+	}
+	return lines
+}
+
+// AssertTranscript is used to wait until the transcript matches the lines specified.
+func (c *Conversation) AssertTranscript(Lines ...string) {
+	c.t.Helper()
+	expected := strings.Join(Lines, "\n")
+	deadline := time.Now().Add(c.Timeout)
+	for {
+		transcript := strings.Join(c.Transcript(), "\n")
+		if transcript == expected {
+			return
+		}
+		if time.Now().After(deadline) {
+			c.t.Fatalf("expected the transcript:\n%s\n\ngot:\n%s", expected, transcript)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
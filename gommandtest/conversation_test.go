@@ -0,0 +1,159 @@
+package gommandtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/gommand"
+)
+
+// TestConversationPrompts is used to test answering questions asked by a command.
+func TestConversationPrompts(t *testing.T) {
+	r, h := newTestHarness(&gommand.RouterConfig{})
+	r.SetCommand(&gommand.Command{
+		Name: "greet",
+		Function: func(ctx *gommand.Context) error {
+			answers, err := gommand.NewForm(nil).Ask("name", "What is your name?", nil).Run(ctx)
+			if err != nil {
+				return err
+			}
+			ok, err := ctx.Confirm("Are you sure?", nil)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				_, err = ctx.Reply("Never mind.")
+				return err
+			}
+			_, err = ctx.Reply("Hi " + answers["name"].(string) + "!")
+			return err
+		},
+	})
+	c := h.Conversation(t, 30, 4)
+	c.Send("%greet")
+	c.Expect("What is your name?")
+	c.Answer("Jake")
+	c.Expect("Are you sure?")
+	c.Answer("maybe")
+	c.Expect("Please try again.")
+	c.Answer("yes")
+	c.Expect("Hi Jake!")
+	c.AssertTranscript(
+		"user: %greet",
+		"bot: What is your name?",
+		"user: Jake",
+		"bot: Are you sure? (yes/no)",
+		"user: maybe",
+		"bot: This is not a valid boolean representation. Please try again.",
+		"user: yes",
+		"bot: Hi Jake!",
+	)
+}
+
+// TestConversationCooldowns is used to test cooldowns expiring with the fake clock.
+func TestConversationCooldowns(t *testing.T) {
+	r, h := newTestHarness(&gommand.RouterConfig{
+		Clock: NewFakeClock(time.Time{}),
+	})
+	r.SetCommand(&gommand.Command{
+		Name:     "ping",
+		Cooldown: &gommand.UserCooldown{MaxRuns: 1, UsageExpires: time.Minute},
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Reply("Pong!")
+			return err
+		},
+	})
+	c := h.Conversation(t, 30, 4)
+	c.Send("%ping")
+	c.Expect("Pong!")
+	c.Send("%ping")
+	c.Expect("This command has a 1 minute cooldown.")
+	c.Advance(time.Minute)
+	c.Send("%ping")
+	c.Expect("Pong!")
+}
+
+// TestConversationMenuLifetime is used to test clicking menu buttons and menu lifetimes with the fake clock.
+func TestConversationMenuLifetime(t *testing.T) {
+	r, h := newTestHarness(&gommand.RouterConfig{
+		Clock: NewFakeClock(time.Time{}),
+	})
+	r.SetCommand(&gommand.Command{
+		Name: "menu",
+		Function: func(ctx *gommand.Context) error {
+			menu := gommand.NewEmbedMenu(&disgord.Embed{Title: "Front"}, ctx)
+			menu.NewChildMenu(&gommand.ChildMenuOptions{
+				Embed:  &disgord.Embed{Title: "Child"},
+				Button: &gommand.MenuButton{Emoji: "▶️", Name: "Forward", Description: "Goes forward."},
+			})
+			return ctx.DisplayEmbedMenuWithLifetime(menu, &gommand.EmbedLifetimeOptions{
+				InactiveLifetime: 5 * time.Minute,
+			})
+		},
+	})
+	c := h.Conversation(t, 30, 4)
+	c.Send("%menu")
+	msg := c.Expect("Front")
+	c.AwaitTimers(1)
+
+	// Clicking the button should reset the inactive lifetime.
+	c.Advance(4 * time.Minute)
+	c.React(msg.ID, "▶️")
+	c.ExpectEdit(msg.ID, "Child")
+	c.Advance(4 * time.Minute)
+	if c.h.Session.Deleted(msg.ID) {
+		t.Fatal("the menu was deleted while active")
+	}
+	c.Advance(time.Minute)
+	c.ExpectDeleted(msg.ID)
+	c.AssertTranscript("user: %menu")
+}
+
+// TestConversationTimeouts is used to test prompts and collectors timing out with the fake clock.
+func TestConversationTimeouts(t *testing.T) {
+	clock := NewFakeClock(time.Time{})
+	r, h := newTestHarness(&gommand.RouterConfig{
+		Clock: clock,
+	})
+	r.SetCommand(&gommand.Command{
+		Name: "confirm",
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Confirm("Are you sure?", &gommand.PromptOptions{Timeout: time.Minute})
+			if _, ok := err.(*gommand.PromptTimeout); ok {
+				_, err = ctx.Reply("Too slow!")
+			}
+			return err
+		},
+	})
+	c := h.Conversation(t, 30, 4)
+	c.Send("%confirm")
+	c.Expect("Are you sure?")
+	c.AwaitTimers(1)
+	c.Advance(time.Minute)
+	c.Expect("Too slow!")
+
+	// Collectors should stop when idle.
+	reasons := make(chan gommand.CollectorEndReason, 1)
+	collector := &gommand.MessageCollector{
+		ChannelID:   30,
+		IdleTimeout: time.Minute,
+		Clock:       clock,
+		OnEnd: func(_ []*disgord.Message, reason gommand.CollectorEndReason) {
+			reasons <- reason
+		},
+	}
+	if err := collector.Start(h.Session); err != nil {
+		t.Fatal(err)
+	}
+	c.AwaitTimers(1)
+	c.Advance(time.Minute)
+	select {
+	case reason := <-reasons:
+		if reason != gommand.CollectorIdle {
+			t.Fatal("expected the collector to be idle, got", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the collector did not stop")
+	}
+}
@@ -0,0 +1,142 @@
+//go:generate go run generate_gateway.go
+package gommandtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Never dies, used for handlers registered without a controller.
+type eternalCtrl struct{}
+
+func (eternalCtrl) OnInsert(disgord.Session) error { return nil }
+func (eternalCtrl) OnRemove(disgord.Session) error { return nil }
+func (eternalCtrl) IsDead() bool                   { return false }
+func (eternalCtrl) Update()                        {}
+
+type gatewayQueryBuilder struct {
+	disgord.GatewayQueryBuilder
+	s *Session
+}
+
+// Gateway is used to get the fake gateway which events can be dispatched through with Dispatch.
+func (s *Session) Gateway() disgord.GatewayQueryBuilder {
+	return &gatewayQueryBuilder{s: s}
+}
+
+func (g *gatewayQueryBuilder) WithContext(context.Context) disgord.GatewayQueryBuilder {
+	return g
+}
+
+func (g *gatewayQueryBuilder) WithMiddleware(first disgord.Middleware, extra ...disgord.Middleware) disgord.SocketHandlerRegistrator {
+	return (&socketHandlerRegistrator{s: g.s}).WithMiddleware(first, extra...)
+}
+
+func (g *gatewayQueryBuilder) WithCtrl(ctrl disgord.HandlerCtrl) disgord.SocketHandlerRegistrator {
+	return (&socketHandlerRegistrator{s: g.s}).WithCtrl(ctrl)
+}
+
+type socketHandlerRegistrator struct {
+	disgord.SocketHandlerRegistrator
+	s           *Session
+	middlewares []disgord.Middleware
+	ctrl        disgord.HandlerCtrl
+}
+
+func (r *socketHandlerRegistrator) WithMiddleware(first disgord.Middleware, extra ...disgord.Middleware) disgord.SocketHandlerRegistrator {
+	x := *r
+	x.middlewares = append(append(append([]disgord.Middleware{}, r.middlewares...), first), extra...)
+	return &x
+}
+
+func (r *socketHandlerRegistrator) WithCtrl(ctrl disgord.HandlerCtrl) disgord.SocketHandlerRegistrator {
+	x := *r
+	x.ctrl = ctrl
+	return &x
+}
+
+// Registers the handler for the event.
+func (r *socketHandlerRegistrator) register(evtName string, handler func(evt interface{})) {
+	ctrl := r.ctrl
+	if ctrl == nil {
+		ctrl = eternalCtrl{}
+	}
+	_ = ctrl.OnInsert(r.s)
+	r.s.handlersLock.Lock()
+	r.s.handlers[evtName] = append(r.s.handlers[evtName], &handlerSpec{
+		middlewares: r.middlewares,
+		ctrl:        ctrl,
+		handler:     handler,
+	})
+	r.s.handlersLock.Unlock()
+}
+
+// Used to make sure a controller is only used by one dispatch at a time, like disgord does.
+var ctrlLock sync.Mutex
+
+// Dispatch is used to dispatch an event (such as disgord.EvtMessageCreate) to all of the handlers registered with the gateway, in the same way disgord does.
+// Handlers are called synchronously, but note that the gommand router handles most events in a new goroutine.
+func (s *Session) Dispatch(EventName string, Event interface{}) {
+	s.handlersLock.Lock()
+	specs := append([]*handlerSpec{}, s.handlers[EventName]...)
+	s.handlersLock.Unlock()
+
+	dead := make(map[*handlerSpec]bool)
+	for _, spec := range specs {
+		ctrlLock.Lock()
+		alive := !spec.ctrl.IsDead()
+		ctrlLock.Unlock()
+		if alive {
+			evt := Event
+			for _, v := range spec.middlewares {
+				if evt = v(evt); evt == nil {
+					break
+				}
+			}
+			if evt != nil {
+				spec.handler(evt)
+				ctrlLock.Lock()
+				spec.ctrl.Update()
+				ctrlLock.Unlock()
+			}
+		}
+		ctrlLock.Lock()
+		if spec.ctrl.IsDead() {
+			dead[spec] = true
+		}
+		ctrlLock.Unlock()
+	}
+
+	// Remove the dead handlers.
+	if len(dead) == 0 {
+		return
+	}
+	s.handlersLock.Lock()
+	alive := make([]*handlerSpec, 0, len(s.handlers[EventName]))
+	for _, v := range s.handlers[EventName] {
+		if !dead[v] {
+			alive = append(alive, v)
+		} else {
+			_ = v.ctrl.OnRemove(s)
+		}
+	}
+	s.handlers[EventName] = alive
+	s.handlersLock.Unlock()
+}
+
+// HandlerCount is used to get the number of handlers registered for an event which are still alive.
+func (s *Session) HandlerCount(EventName string) int {
+	s.handlersLock.Lock()
+	defer s.handlersLock.Unlock()
+	count := 0
+	ctrlLock.Lock()
+	for _, v := range s.handlers[EventName] {
+		if !v.ctrl.IsDead() {
+			count++
+		}
+	}
+	ctrlLock.Unlock()
+	return count
+}
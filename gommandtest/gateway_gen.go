@@ -0,0 +1,356 @@
+// Code generated by gommandtest. DO NOT EDIT.
+package gommandtest
+
+import "github.com/andersfylling/disgord"
+
+func (r *socketHandlerRegistrator) Ready(handler disgord.HandlerReady, moreHandlers ...disgord.HandlerReady) {
+	for _, h := range append([]disgord.HandlerReady{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtReady, func(evt interface{}) { h(r.s, evt.(*disgord.Ready)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) ChannelCreate(handler disgord.HandlerChannelCreate, moreHandlers ...disgord.HandlerChannelCreate) {
+	for _, h := range append([]disgord.HandlerChannelCreate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtChannelCreate, func(evt interface{}) { h(r.s, evt.(*disgord.ChannelCreate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) ChannelUpdate(handler disgord.HandlerChannelUpdate, moreHandlers ...disgord.HandlerChannelUpdate) {
+	for _, h := range append([]disgord.HandlerChannelUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtChannelUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.ChannelUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) ChannelDelete(handler disgord.HandlerChannelDelete, moreHandlers ...disgord.HandlerChannelDelete) {
+	for _, h := range append([]disgord.HandlerChannelDelete{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtChannelDelete, func(evt interface{}) { h(r.s, evt.(*disgord.ChannelDelete)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) ChannelPinsUpdate(handler disgord.HandlerChannelPinsUpdate, moreHandlers ...disgord.HandlerChannelPinsUpdate) {
+	for _, h := range append([]disgord.HandlerChannelPinsUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtChannelPinsUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.ChannelPinsUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) TypingStart(handler disgord.HandlerTypingStart, moreHandlers ...disgord.HandlerTypingStart) {
+	for _, h := range append([]disgord.HandlerTypingStart{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtTypingStart, func(evt interface{}) { h(r.s, evt.(*disgord.TypingStart)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) InviteDelete(handler disgord.HandlerInviteDelete, moreHandlers ...disgord.HandlerInviteDelete) {
+	for _, h := range append([]disgord.HandlerInviteDelete{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtInviteDelete, func(evt interface{}) { h(r.s, evt.(*disgord.InviteDelete)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageCreate(handler disgord.HandlerMessageCreate, moreHandlers ...disgord.HandlerMessageCreate) {
+	for _, h := range append([]disgord.HandlerMessageCreate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageCreate, func(evt interface{}) { h(r.s, evt.(*disgord.MessageCreate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageUpdate(handler disgord.HandlerMessageUpdate, moreHandlers ...disgord.HandlerMessageUpdate) {
+	for _, h := range append([]disgord.HandlerMessageUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.MessageUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageDelete(handler disgord.HandlerMessageDelete, moreHandlers ...disgord.HandlerMessageDelete) {
+	for _, h := range append([]disgord.HandlerMessageDelete{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageDelete, func(evt interface{}) { h(r.s, evt.(*disgord.MessageDelete)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageDeleteBulk(handler disgord.HandlerMessageDeleteBulk, moreHandlers ...disgord.HandlerMessageDeleteBulk) {
+	for _, h := range append([]disgord.HandlerMessageDeleteBulk{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageDeleteBulk, func(evt interface{}) { h(r.s, evt.(*disgord.MessageDeleteBulk)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageReactionAdd(handler disgord.HandlerMessageReactionAdd, moreHandlers ...disgord.HandlerMessageReactionAdd) {
+	for _, h := range append([]disgord.HandlerMessageReactionAdd{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageReactionAdd, func(evt interface{}) { h(r.s, evt.(*disgord.MessageReactionAdd)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageReactionRemove(handler disgord.HandlerMessageReactionRemove, moreHandlers ...disgord.HandlerMessageReactionRemove) {
+	for _, h := range append([]disgord.HandlerMessageReactionRemove{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageReactionRemove, func(evt interface{}) { h(r.s, evt.(*disgord.MessageReactionRemove)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) MessageReactionRemoveAll(handler disgord.HandlerMessageReactionRemoveAll, moreHandlers ...disgord.HandlerMessageReactionRemoveAll) {
+	for _, h := range append([]disgord.HandlerMessageReactionRemoveAll{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtMessageReactionRemoveAll, func(evt interface{}) { h(r.s, evt.(*disgord.MessageReactionRemoveAll)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildEmojisUpdate(handler disgord.HandlerGuildEmojisUpdate, moreHandlers ...disgord.HandlerGuildEmojisUpdate) {
+	for _, h := range append([]disgord.HandlerGuildEmojisUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildEmojisUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.GuildEmojisUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildCreate(handler disgord.HandlerGuildCreate, moreHandlers ...disgord.HandlerGuildCreate) {
+	for _, h := range append([]disgord.HandlerGuildCreate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildCreate, func(evt interface{}) { h(r.s, evt.(*disgord.GuildCreate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildUpdate(handler disgord.HandlerGuildUpdate, moreHandlers ...disgord.HandlerGuildUpdate) {
+	for _, h := range append([]disgord.HandlerGuildUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.GuildUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildDelete(handler disgord.HandlerGuildDelete, moreHandlers ...disgord.HandlerGuildDelete) {
+	for _, h := range append([]disgord.HandlerGuildDelete{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildDelete, func(evt interface{}) { h(r.s, evt.(*disgord.GuildDelete)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildBanAdd(handler disgord.HandlerGuildBanAdd, moreHandlers ...disgord.HandlerGuildBanAdd) {
+	for _, h := range append([]disgord.HandlerGuildBanAdd{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildBanAdd, func(evt interface{}) { h(r.s, evt.(*disgord.GuildBanAdd)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildBanRemove(handler disgord.HandlerGuildBanRemove, moreHandlers ...disgord.HandlerGuildBanRemove) {
+	for _, h := range append([]disgord.HandlerGuildBanRemove{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildBanRemove, func(evt interface{}) { h(r.s, evt.(*disgord.GuildBanRemove)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildMemberAdd(handler disgord.HandlerGuildMemberAdd, moreHandlers ...disgord.HandlerGuildMemberAdd) {
+	for _, h := range append([]disgord.HandlerGuildMemberAdd{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildMemberAdd, func(evt interface{}) { h(r.s, evt.(*disgord.GuildMemberAdd)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildMemberRemove(handler disgord.HandlerGuildMemberRemove, moreHandlers ...disgord.HandlerGuildMemberRemove) {
+	for _, h := range append([]disgord.HandlerGuildMemberRemove{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildMemberRemove, func(evt interface{}) { h(r.s, evt.(*disgord.GuildMemberRemove)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildMemberUpdate(handler disgord.HandlerGuildMemberUpdate, moreHandlers ...disgord.HandlerGuildMemberUpdate) {
+	for _, h := range append([]disgord.HandlerGuildMemberUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildMemberUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.GuildMemberUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildRoleCreate(handler disgord.HandlerGuildRoleCreate, moreHandlers ...disgord.HandlerGuildRoleCreate) {
+	for _, h := range append([]disgord.HandlerGuildRoleCreate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildRoleCreate, func(evt interface{}) { h(r.s, evt.(*disgord.GuildRoleCreate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildRoleUpdate(handler disgord.HandlerGuildRoleUpdate, moreHandlers ...disgord.HandlerGuildRoleUpdate) {
+	for _, h := range append([]disgord.HandlerGuildRoleUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildRoleUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.GuildRoleUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) GuildRoleDelete(handler disgord.HandlerGuildRoleDelete, moreHandlers ...disgord.HandlerGuildRoleDelete) {
+	for _, h := range append([]disgord.HandlerGuildRoleDelete{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtGuildRoleDelete, func(evt interface{}) { h(r.s, evt.(*disgord.GuildRoleDelete)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) PresenceUpdate(handler disgord.HandlerPresenceUpdate, moreHandlers ...disgord.HandlerPresenceUpdate) {
+	for _, h := range append([]disgord.HandlerPresenceUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtPresenceUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.PresenceUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) UserUpdate(handler disgord.HandlerUserUpdate, moreHandlers ...disgord.HandlerUserUpdate) {
+	for _, h := range append([]disgord.HandlerUserUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtUserUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.UserUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) VoiceStateUpdate(handler disgord.HandlerVoiceStateUpdate, moreHandlers ...disgord.HandlerVoiceStateUpdate) {
+	for _, h := range append([]disgord.HandlerVoiceStateUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtVoiceStateUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.VoiceStateUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) VoiceServerUpdate(handler disgord.HandlerVoiceServerUpdate, moreHandlers ...disgord.HandlerVoiceServerUpdate) {
+	for _, h := range append([]disgord.HandlerVoiceServerUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtVoiceServerUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.VoiceServerUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) WebhooksUpdate(handler disgord.HandlerWebhooksUpdate, moreHandlers ...disgord.HandlerWebhooksUpdate) {
+	for _, h := range append([]disgord.HandlerWebhooksUpdate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtWebhooksUpdate, func(evt interface{}) { h(r.s, evt.(*disgord.WebhooksUpdate)) })
+	}
+}
+
+func (r *socketHandlerRegistrator) InviteCreate(handler disgord.HandlerInviteCreate, moreHandlers ...disgord.HandlerInviteCreate) {
+	for _, h := range append([]disgord.HandlerInviteCreate{handler}, moreHandlers...) {
+		h := h
+		r.register(disgord.EvtInviteCreate, func(evt interface{}) { h(r.s, evt.(*disgord.InviteCreate)) })
+	}
+}
+
+func (g *gatewayQueryBuilder) Ready(handler disgord.HandlerReady, moreHandlers ...disgord.HandlerReady) {
+	(&socketHandlerRegistrator{s: g.s}).Ready(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) ChannelCreate(handler disgord.HandlerChannelCreate, moreHandlers ...disgord.HandlerChannelCreate) {
+	(&socketHandlerRegistrator{s: g.s}).ChannelCreate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) ChannelUpdate(handler disgord.HandlerChannelUpdate, moreHandlers ...disgord.HandlerChannelUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).ChannelUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) ChannelDelete(handler disgord.HandlerChannelDelete, moreHandlers ...disgord.HandlerChannelDelete) {
+	(&socketHandlerRegistrator{s: g.s}).ChannelDelete(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) ChannelPinsUpdate(handler disgord.HandlerChannelPinsUpdate, moreHandlers ...disgord.HandlerChannelPinsUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).ChannelPinsUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) TypingStart(handler disgord.HandlerTypingStart, moreHandlers ...disgord.HandlerTypingStart) {
+	(&socketHandlerRegistrator{s: g.s}).TypingStart(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) InviteDelete(handler disgord.HandlerInviteDelete, moreHandlers ...disgord.HandlerInviteDelete) {
+	(&socketHandlerRegistrator{s: g.s}).InviteDelete(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageCreate(handler disgord.HandlerMessageCreate, moreHandlers ...disgord.HandlerMessageCreate) {
+	(&socketHandlerRegistrator{s: g.s}).MessageCreate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageUpdate(handler disgord.HandlerMessageUpdate, moreHandlers ...disgord.HandlerMessageUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).MessageUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageDelete(handler disgord.HandlerMessageDelete, moreHandlers ...disgord.HandlerMessageDelete) {
+	(&socketHandlerRegistrator{s: g.s}).MessageDelete(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageDeleteBulk(handler disgord.HandlerMessageDeleteBulk, moreHandlers ...disgord.HandlerMessageDeleteBulk) {
+	(&socketHandlerRegistrator{s: g.s}).MessageDeleteBulk(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageReactionAdd(handler disgord.HandlerMessageReactionAdd, moreHandlers ...disgord.HandlerMessageReactionAdd) {
+	(&socketHandlerRegistrator{s: g.s}).MessageReactionAdd(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageReactionRemove(handler disgord.HandlerMessageReactionRemove, moreHandlers ...disgord.HandlerMessageReactionRemove) {
+	(&socketHandlerRegistrator{s: g.s}).MessageReactionRemove(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) MessageReactionRemoveAll(handler disgord.HandlerMessageReactionRemoveAll, moreHandlers ...disgord.HandlerMessageReactionRemoveAll) {
+	(&socketHandlerRegistrator{s: g.s}).MessageReactionRemoveAll(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildEmojisUpdate(handler disgord.HandlerGuildEmojisUpdate, moreHandlers ...disgord.HandlerGuildEmojisUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).GuildEmojisUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildCreate(handler disgord.HandlerGuildCreate, moreHandlers ...disgord.HandlerGuildCreate) {
+	(&socketHandlerRegistrator{s: g.s}).GuildCreate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildUpdate(handler disgord.HandlerGuildUpdate, moreHandlers ...disgord.HandlerGuildUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).GuildUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildDelete(handler disgord.HandlerGuildDelete, moreHandlers ...disgord.HandlerGuildDelete) {
+	(&socketHandlerRegistrator{s: g.s}).GuildDelete(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildBanAdd(handler disgord.HandlerGuildBanAdd, moreHandlers ...disgord.HandlerGuildBanAdd) {
+	(&socketHandlerRegistrator{s: g.s}).GuildBanAdd(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildBanRemove(handler disgord.HandlerGuildBanRemove, moreHandlers ...disgord.HandlerGuildBanRemove) {
+	(&socketHandlerRegistrator{s: g.s}).GuildBanRemove(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildMemberAdd(handler disgord.HandlerGuildMemberAdd, moreHandlers ...disgord.HandlerGuildMemberAdd) {
+	(&socketHandlerRegistrator{s: g.s}).GuildMemberAdd(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildMemberRemove(handler disgord.HandlerGuildMemberRemove, moreHandlers ...disgord.HandlerGuildMemberRemove) {
+	(&socketHandlerRegistrator{s: g.s}).GuildMemberRemove(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildMemberUpdate(handler disgord.HandlerGuildMemberUpdate, moreHandlers ...disgord.HandlerGuildMemberUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).GuildMemberUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildRoleCreate(handler disgord.HandlerGuildRoleCreate, moreHandlers ...disgord.HandlerGuildRoleCreate) {
+	(&socketHandlerRegistrator{s: g.s}).GuildRoleCreate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildRoleUpdate(handler disgord.HandlerGuildRoleUpdate, moreHandlers ...disgord.HandlerGuildRoleUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).GuildRoleUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) GuildRoleDelete(handler disgord.HandlerGuildRoleDelete, moreHandlers ...disgord.HandlerGuildRoleDelete) {
+	(&socketHandlerRegistrator{s: g.s}).GuildRoleDelete(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) PresenceUpdate(handler disgord.HandlerPresenceUpdate, moreHandlers ...disgord.HandlerPresenceUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).PresenceUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) UserUpdate(handler disgord.HandlerUserUpdate, moreHandlers ...disgord.HandlerUserUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).UserUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) VoiceStateUpdate(handler disgord.HandlerVoiceStateUpdate, moreHandlers ...disgord.HandlerVoiceStateUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).VoiceStateUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) VoiceServerUpdate(handler disgord.HandlerVoiceServerUpdate, moreHandlers ...disgord.HandlerVoiceServerUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).VoiceServerUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) WebhooksUpdate(handler disgord.HandlerWebhooksUpdate, moreHandlers ...disgord.HandlerWebhooksUpdate) {
+	(&socketHandlerRegistrator{s: g.s}).WebhooksUpdate(handler, moreHandlers...)
+}
+
+func (g *gatewayQueryBuilder) InviteCreate(handler disgord.HandlerInviteCreate, moreHandlers ...disgord.HandlerInviteCreate) {
+	(&socketHandlerRegistrator{s: g.s}).InviteCreate(handler, moreHandlers...)
+}
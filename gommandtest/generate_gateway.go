@@ -0,0 +1,62 @@
+// +build ignore
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"text/template"
+)
+
+func die(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	b, err := ioutil.ReadFile("gateway.tmpl")
+	die(err)
+
+	tpl, err := template.New("gateway").Parse(string(b))
+	die(err)
+
+	f, err := os.Create("gateway_gen.go")
+	die(err)
+	defer f.Close()
+
+	die(tpl.Execute(f, []string{
+		"Ready",
+		"ChannelCreate",
+		"ChannelUpdate",
+		"ChannelDelete",
+		"ChannelPinsUpdate",
+		"TypingStart",
+		"InviteDelete",
+		"MessageCreate",
+		"MessageUpdate",
+		"MessageDelete",
+		"MessageDeleteBulk",
+		"MessageReactionAdd",
+		"MessageReactionRemove",
+		"MessageReactionRemoveAll",
+		"GuildEmojisUpdate",
+		"GuildCreate",
+		"GuildUpdate",
+		"GuildDelete",
+		"GuildBanAdd",
+		"GuildBanRemove",
+		"GuildMemberAdd",
+		"GuildMemberRemove",
+		"GuildMemberUpdate",
+		"GuildRoleCreate",
+		"GuildRoleUpdate",
+		"GuildRoleDelete",
+		"PresenceUpdate",
+		"UserUpdate",
+		"VoiceStateUpdate",
+		"VoiceServerUpdate",
+		"WebhooksUpdate",
+		"InviteCreate",
+	}))
+}
@@ -0,0 +1,127 @@
+package gommandtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/gommand"
+)
+
+// Creates a session with a guild containing a moderator and a normal user.
+func seededSession() *Session {
+	s := NewSession(&disgord.User{ID: 1, Username: "bot", Bot: true})
+	s.AddGuild(&disgord.Guild{ID: 10, OwnerID: 2})
+	s.AddRole(10, &disgord.Role{ID: 20, Permissions: disgord.PermissionBanMembers})
+	s.AddRole(10, &disgord.Role{ID: 21, Permissions: disgord.PermissionAdministrator})
+	s.AddChannel(&disgord.Channel{ID: 30, GuildID: 10})
+	s.AddMember(10, &disgord.Member{User: s.BotUser, Roles: []disgord.Snowflake{21}})
+	s.AddMember(10, &disgord.Member{User: &disgord.User{ID: 3, Username: "moderator"}, Roles: []disgord.Snowflake{20}})
+	s.AddMember(10, &disgord.Member{User: &disgord.User{ID: 4, Username: "user"}})
+	return s
+}
+
+// Creates a router which replies with any errors, along with a harness using the seeded session. If the prefix check is not set, the prefix is "%".
+func newTestHarness(Config *gommand.RouterConfig) (*gommand.Router, *Harness) {
+	if Config.PrefixCheck == nil {
+		Config.PrefixCheck = gommand.StaticPrefix("%")
+	}
+	r := gommand.NewRouter(Config)
+	r.AddErrorHandler(func(ctx *gommand.Context, err error) bool {
+		_, _ = ctx.Reply(err.Error())
+		return true
+	})
+	return r, NewHarness(r, seededSession())
+}
+
+// TestHarness is used to test running commands through the harness.
+func TestHarness(t *testing.T) {
+	r := gommand.NewRouter(&gommand.RouterConfig{
+		PrefixCheck: gommand.StaticPrefix("%"),
+	})
+	r.SetCommand(&gommand.Command{
+		Name:                 "ban",
+		PermissionValidators: []gommand.PermissionValidator{gommand.BAN_MEMBERS(gommand.CheckMembersUserPermissions)},
+		ArgTransformers:      []gommand.ArgTransformer{{Function: gommand.MemberTransformer}},
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Reply("Banned " + ctx.Args[0].(*disgord.Member).User.Username + ".")
+			return err
+		},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *gommand.Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	h := NewHarness(r, seededSession())
+
+	// The moderator should be able to run the command.
+	h.SendMessage(30, 3, "%ban <@4>")
+	if lastErr != nil {
+		t.Fatal(lastErr)
+	}
+	AssertLastMessageContains(t, h.Session, 30, "Banned user.")
+
+	// The user should not.
+	h.SendMessage(30, 4, "%ban <@3>")
+	if _, ok := lastErr.(*gommand.IncorrectPermissions); !ok {
+		t.Fatal("expected incorrect permissions, got", lastErr)
+	}
+	AssertMessageCount(t, h.Session, 30, 1)
+}
+
+// TestSessionUpdate is used to test editing messages with the disgord update builder.
+func TestSessionUpdate(t *testing.T) {
+	s := seededSession()
+	msg, err := s.SendMsg(30, "Loading...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Channel(30).Message(msg.ID).Update().SetContent("").SetEmbed(&disgord.Embed{Title: "Done"}).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg = s.Message(30, msg.ID)
+	if msg.Content != "" || len(msg.Embeds) != 1 || msg.Embeds[0].Title != "Done" {
+		t.Fatal("message was not updated")
+	}
+	if _, err = s.Channel(30).Message(1).Update().SetContent("a").Execute(); err == nil {
+		t.Fatal("updating a message which does not exist did not error")
+	}
+}
+
+// TestHarnessMenus is used to test embed menus through the harness.
+func TestHarnessMenus(t *testing.T) {
+	r := gommand.NewRouter(&gommand.RouterConfig{
+		PrefixCheck: gommand.StaticPrefix("%"),
+	})
+	r.SetCommand(&gommand.Command{
+		Name: "menu",
+		Function: func(ctx *gommand.Context) error {
+			menu := gommand.NewEmbedMenu(&disgord.Embed{Title: "Front"}, ctx)
+			menu.NewChildMenu(&gommand.ChildMenuOptions{
+				Embed:  &disgord.Embed{Title: "Child"},
+				Button: &gommand.MenuButton{Emoji: "▶️", Name: "Forward", Description: "Goes forward."},
+			})
+			return ctx.DisplayEmbedMenu(menu)
+		},
+	})
+	h := NewHarness(r, seededSession())
+	h.SendMessage(30, 4, "%menu")
+	msg := AssertLastMessageContains(t, h.Session, 30, "Front")
+	AssertReactions(t, h.Session, msg.ID, "▶️")
+
+	// Other users reacting should not change the menu.
+	h.Session.AddReaction(30, msg.ID, 3, "▶️")
+	h.Session.AddReaction(30, msg.ID, 4, "▶️")
+	deadline := time.Now().Add(time.Second)
+	for h.Session.Message(30, msg.ID).Embeds[0].Title != "Child" {
+		if time.Now().After(deadline) {
+			t.Fatal("menu did not change")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	AssertEmbed(t, h.Session.Message(30, msg.ID), func(embed *disgord.Embed) bool {
+		return embed.Title == "Child"
+	})
+}
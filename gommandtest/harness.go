@@ -0,0 +1,86 @@
+package gommandtest
+
+import (
+	"time"
+
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/gommand"
+)
+
+// Harness is used to run messages through a router using a fake session.
+type Harness struct {
+	// Session is the fake session which the router is hooked into.
+	Session *Session
+
+	// Router is the router which is being tested.
+	Router *gommand.Router
+
+	// ShardID is the shard ID which messages are processed with.
+	ShardID uint
+
+	// Clock is the fake clock which the router uses. This is nil if the router is not using a FakeClock.
+	Clock *FakeClock
+
+	// The number of handlers the router registered for each event. Anything past this is waiting within a command.
+	baseHandlers map[string]int
+}
+
+// NewHarness is used to hook the router into the fake session and mark the bot as ready.
+func NewHarness(Router *gommand.Router, Session *Session) *Harness {
+	Router.Hook(Session)
+	Session.Dispatch(disgord.EvtReady, &disgord.Ready{User: Session.BotUser})
+	clock, _ := Router.Clock.(*FakeClock)
+	return &Harness{
+		Session: Session,
+		Router:  Router,
+		Clock:   clock,
+		baseHandlers: map[string]int{
+			disgord.EvtMessageCreate:      Session.HandlerCount(disgord.EvtMessageCreate),
+			disgord.EvtMessageReactionAdd: Session.HandlerCount(disgord.EvtMessageReactionAdd),
+		},
+	}
+}
+
+// Message is used to create a message from the author in the channel, patching in the seeded user and member.
+// The message is added to the channel, but is not processed.
+func (h *Harness) Message(ChannelID, AuthorID disgord.Snowflake, Content string) *disgord.Message {
+	s := h.Session
+	s.lock.Lock()
+	author, ok := s.users[AuthorID]
+	if ok {
+		author = disgord.DeepCopy(author).(*disgord.User)
+	} else {
+		author = &disgord.User{ID: AuthorID}
+	}
+	var guildID disgord.Snowflake
+	if channel, ok := s.channels[ChannelID]; ok {
+		guildID = channel.GuildID
+	}
+	member, ok := s.members[guildID][AuthorID]
+	if ok {
+		member = disgord.DeepCopy(member).(*disgord.Member)
+	} else {
+		member = &disgord.Member{UserID: AuthorID}
+	}
+	msg := &disgord.Message{
+		ID:        s.newID(),
+		ChannelID: ChannelID,
+		GuildID:   guildID,
+		Author:    author,
+		Member:    member,
+		Content:   Content,
+		Type:      disgord.MessageTypeDefault,
+		Timestamp: disgord.Time{Time: time.Now()},
+	}
+	s.messages[ChannelID] = append(s.messages[ChannelID], msg)
+	s.lock.Unlock()
+	return disgord.DeepCopy(msg).(*disgord.Message)
+}
+
+// SendMessage is used to send a message from the author in the channel and run it through the command processor.
+// This blocks until the command processor is done, and returns the message which was sent.
+func (h *Harness) SendMessage(ChannelID, AuthorID disgord.Snowflake, Content string) *disgord.Message {
+	msg := h.Message(ChannelID, AuthorID, Content)
+	h.Router.CommandProcessor(h.Session, h.ShardID, disgord.DeepCopy(msg).(*disgord.Message), true)
+	return msg
+}
@@ -0,0 +1,151 @@
+package gommandtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Some of the disgord REST builders (such as the one returned by Update on a message) cannot be faked since their types are not exported.
+// To support these, the session creates a real disgord client which sends its requests to this transport, which applies them to the session rather than sending them to Discord.
+type restTransport struct {
+	s *Session
+}
+
+// Encodes the value as JSON with the snowflakes as strings like Discord does, since disgord cannot parse some snowflakes which are numbers.
+func discordJSON(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var x interface{}
+	if err := d.Decode(&x); err != nil {
+		return nil, err
+	}
+	return json.Marshal(quoteSnowflakes(x, false))
+}
+
+// Turns the numbers which are snowflakes into strings. Snowflake is used to mark that the value is a list of snowflakes.
+func quoteSnowflakes(v interface{}, Snowflake bool) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, item := range x {
+			x[k] = quoteSnowflakes(item, k == "id" || strings.HasSuffix(k, "_id") || k == "roles" || k == "mention_roles")
+		}
+	case []interface{}:
+		for i, item := range x {
+			x[i] = quoteSnowflakes(item, Snowflake)
+		}
+	case json.Number:
+		if Snowflake {
+			return string(x)
+		}
+	}
+	return v
+}
+
+// Creates a JSON response with the status code given.
+func jsonResponse(req *http.Request, Status int, Body interface{}) (*http.Response, error) {
+	b, err := discordJSON(Body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: Status,
+		Status:     http.StatusText(Status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		Request:    req,
+	}, nil
+}
+
+// Creates a response for something which does not exist, similarly to a 404 from Discord.
+func notFoundResponse(req *http.Request, item string) (*http.Response, error) {
+	return jsonResponse(req, http.StatusNotFound, map[string]interface{}{"code": 0, "message": "Unknown " + item + "."})
+}
+
+// RoundTrip is used to handle a request from the disgord client.
+func (t *restTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	// Remove the "api/vX" part of the path.
+	if len(path) >= 2 && path[0] == "api" {
+		path = path[2:]
+	}
+
+	switch {
+	case req.Method == http.MethodGet && len(path) == 2 && path[0] == "users" && path[1] == "@me":
+		// Used by disgord to get the bot user when the client is created.
+		return jsonResponse(req, http.StatusOK, t.s.BotUser)
+	case req.Method == http.MethodPatch && len(path) == 4 && path[0] == "channels" && path[2] == "messages":
+		cid, err := disgord.GetSnowflake(path[1])
+		if err != nil {
+			return notFoundResponse(req, "channel")
+		}
+		mid, err := disgord.GetSnowflake(path[3])
+		if err != nil {
+			return notFoundResponse(req, "message")
+		}
+		var params map[string]json.RawMessage
+		if req.Body != nil {
+			if err := json.NewDecoder(req.Body).Decode(&params); err != nil {
+				return nil, err
+			}
+		}
+		msg, err := t.s.editMessage(cid, mid, params)
+		if err != nil {
+			return nil, err
+		}
+		if msg == nil {
+			return notFoundResponse(req, "message")
+		}
+		return jsonResponse(req, http.StatusOK, msg)
+	}
+	panic("gommandtest: " + req.Method + " " + req.URL.Path + " is not faked")
+}
+
+// Gets the disgord client which sends its requests to the session.
+func (s *Session) restClient() *disgord.Client {
+	s.restOnce.Do(func() {
+		s.rest = disgord.New(disgord.Config{
+			BotToken:     "gommandtest",
+			DisableCache: true,
+			HTTPClient:   &http.Client{Transport: &restTransport{s: s}},
+		})
+	})
+	return s.rest
+}
+
+// Applies the JSON parameters of a message edit to the message. If the message doesn't exist, this returns nil.
+func (s *Session) editMessage(ChannelID, MessageID disgord.Snowflake, Params map[string]json.RawMessage) (*disgord.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	msg := s.getMessage(ChannelID, MessageID)
+	if msg == nil {
+		return nil, nil
+	}
+	if raw, ok := Params["content"]; ok {
+		var content string
+		if err := json.Unmarshal(raw, &content); err != nil {
+			return nil, err
+		}
+		msg.Content = content
+	}
+	if raw, ok := Params["embed"]; ok {
+		var embed *disgord.Embed
+		if err := json.Unmarshal(raw, &embed); err != nil {
+			return nil, err
+		}
+		msg.Embeds = []*disgord.Embed{}
+		if embed != nil {
+			msg.Embeds = []*disgord.Embed{embed}
+		}
+	}
+	return disgord.DeepCopy(msg).(*disgord.Message), nil
+}
@@ -0,0 +1,293 @@
+package gommandtest
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Standardises an emoji passed to the reaction query builder.
+func standardiseEmoji(emoji interface{}) string {
+	switch x := emoji.(type) {
+	case string:
+		return x
+	case *disgord.Emoji:
+		if x.ID == 0 {
+			return x.Name
+		}
+		return x.Name + ":" + x.ID.String()
+	case disgord.Emoji:
+		return standardiseEmoji(&x)
+	}
+	return ""
+}
+
+// WithContext is used to set the context. This is ignored by the fake session.
+func (s *Session) WithContext(context.Context) disgord.ClientQueryBuilderExecutables {
+	return s
+}
+
+// User is used to get a user query builder.
+func (s *Session) User(UserID disgord.Snowflake) disgord.UserQueryBuilder {
+	return &userQueryBuilder{s: s, uid: UserID}
+}
+
+// Guild is used to get a guild query builder.
+func (s *Session) Guild(GuildID disgord.Snowflake) disgord.GuildQueryBuilder {
+	return &guildQueryBuilder{s: s, gid: GuildID}
+}
+
+// Channel is used to get a channel query builder.
+func (s *Session) Channel(ChannelID disgord.Snowflake) disgord.ChannelQueryBuilder {
+	return &channelQueryBuilder{s: s, cid: ChannelID}
+}
+
+type userQueryBuilder struct {
+	disgord.UserQueryBuilder
+	s   *Session
+	uid disgord.Snowflake
+}
+
+func (u *userQueryBuilder) WithContext(context.Context) disgord.UserQueryBuilder {
+	return u
+}
+
+func (u *userQueryBuilder) Get(...disgord.Flag) (*disgord.User, error) {
+	u.s.lock.RLock()
+	defer u.s.lock.RUnlock()
+	user, ok := u.s.users[u.uid]
+	if !ok {
+		return nil, notFound("user")
+	}
+	return disgord.DeepCopy(user).(*disgord.User), nil
+}
+
+type guildQueryBuilder struct {
+	disgord.GuildQueryBuilder
+	s   *Session
+	gid disgord.Snowflake
+}
+
+func (g *guildQueryBuilder) WithContext(context.Context) disgord.GuildQueryBuilder {
+	return g
+}
+
+func (g *guildQueryBuilder) Get(...disgord.Flag) (*disgord.Guild, error) {
+	g.s.lock.RLock()
+	defer g.s.lock.RUnlock()
+	guild, ok := g.s.guilds[g.gid]
+	if !ok {
+		return nil, notFound("guild")
+	}
+	return disgord.DeepCopy(guild).(*disgord.Guild), nil
+}
+
+func (g *guildQueryBuilder) GetRoles(...disgord.Flag) ([]*disgord.Role, error) {
+	g.s.lock.RLock()
+	defer g.s.lock.RUnlock()
+	if _, ok := g.s.guilds[g.gid]; !ok {
+		return nil, notFound("guild")
+	}
+	roles := make([]*disgord.Role, len(g.s.roles[g.gid]))
+	for i, v := range g.s.roles[g.gid] {
+		roles[i] = disgord.DeepCopy(v).(*disgord.Role)
+	}
+	return roles, nil
+}
+
+func (g *guildQueryBuilder) GetChannels(...disgord.Flag) ([]*disgord.Channel, error) {
+	g.s.lock.RLock()
+	defer g.s.lock.RUnlock()
+	if _, ok := g.s.guilds[g.gid]; !ok {
+		return nil, notFound("guild")
+	}
+	channels := make([]*disgord.Channel, 0)
+	for _, v := range g.s.channels {
+		if v.GuildID == g.gid {
+			channels = append(channels, disgord.DeepCopy(v).(*disgord.Channel))
+		}
+	}
+	return channels, nil
+}
+
+func (g *guildQueryBuilder) Member(UserID disgord.Snowflake) disgord.GuildMemberQueryBuilder {
+	return &guildMemberQueryBuilder{s: g.s, gid: g.gid, uid: UserID}
+}
+
+type guildMemberQueryBuilder struct {
+	disgord.GuildMemberQueryBuilder
+	s   *Session
+	gid disgord.Snowflake
+	uid disgord.Snowflake
+}
+
+func (m *guildMemberQueryBuilder) WithContext(context.Context) disgord.GuildMemberQueryBuilder {
+	return m
+}
+
+func (m *guildMemberQueryBuilder) Get(...disgord.Flag) (*disgord.Member, error) {
+	m.s.lock.RLock()
+	defer m.s.lock.RUnlock()
+	member, ok := m.s.members[m.gid][m.uid]
+	if !ok {
+		return nil, notFound("member")
+	}
+	member = disgord.DeepCopy(member).(*disgord.Member)
+	member.GuildID = m.gid
+	member.UserID = m.uid
+	return member, nil
+}
+
+func (m *guildMemberQueryBuilder) GetPermissions(...disgord.Flag) (disgord.PermissionBit, error) {
+	member, err := m.Get()
+	if err != nil {
+		return 0, err
+	}
+	return member.GetPermissions(context.Background(), m.s)
+}
+
+type channelQueryBuilder struct {
+	disgord.ChannelQueryBuilder
+	s   *Session
+	cid disgord.Snowflake
+}
+
+func (c *channelQueryBuilder) WithContext(context.Context) disgord.ChannelQueryBuilder {
+	return c
+}
+
+func (c *channelQueryBuilder) Get(...disgord.Flag) (*disgord.Channel, error) {
+	c.s.lock.RLock()
+	defer c.s.lock.RUnlock()
+	channel, ok := c.s.channels[c.cid]
+	if !ok {
+		return nil, notFound("channel")
+	}
+	return disgord.DeepCopy(channel).(*disgord.Channel), nil
+}
+
+func (c *channelQueryBuilder) Message(MessageID disgord.Snowflake) disgord.MessageQueryBuilder {
+	// The real message query builder is embedded so that builders which cannot be faked (such as Update) send their requests to the session.
+	return &messageQueryBuilder{MessageQueryBuilder: c.s.restClient().Channel(c.cid).Message(MessageID), s: c.s, cid: c.cid, mid: MessageID}
+}
+
+type messageQueryBuilder struct {
+	disgord.MessageQueryBuilder
+	s   *Session
+	cid disgord.Snowflake
+	mid disgord.Snowflake
+}
+
+func (m *messageQueryBuilder) WithContext(context.Context) disgord.MessageQueryBuilder {
+	return m
+}
+
+func (m *messageQueryBuilder) Get(...disgord.Flag) (*disgord.Message, error) {
+	msg := m.s.Message(m.cid, m.mid)
+	if msg == nil {
+		return nil, notFound("message")
+	}
+	return msg, nil
+}
+
+func (m *messageQueryBuilder) Delete(...disgord.Flag) error {
+	m.s.lock.Lock()
+	if m.s.getMessage(m.cid, m.mid) == nil {
+		m.s.lock.Unlock()
+		return notFound("message")
+	}
+	m.s.deleted[m.mid] = true
+	m.s.lock.Unlock()
+
+	// Dispatch the delete event like Discord would.
+	m.s.Dispatch(disgord.EvtMessageDelete, &disgord.MessageDelete{ChannelID: m.cid, MessageID: m.mid})
+	return nil
+}
+
+func (m *messageQueryBuilder) DeleteAllReactions(...disgord.Flag) error {
+	m.s.lock.Lock()
+	defer m.s.lock.Unlock()
+	if m.s.getMessage(m.cid, m.mid) == nil {
+		return notFound("message")
+	}
+	delete(m.s.reactions, m.mid)
+	return nil
+}
+
+// Edits the message with the JSON parameters given.
+func (m *messageQueryBuilder) edit(Params map[string]interface{}) (*disgord.Message, error) {
+	raw := map[string]json.RawMessage{}
+	for k, v := range Params {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		raw[k] = b
+	}
+	msg, err := m.s.editMessage(m.cid, m.mid, raw)
+	if err == nil && msg == nil {
+		err = notFound("message")
+	}
+	return msg, err
+}
+
+func (m *messageQueryBuilder) SetContent(content string) (*disgord.Message, error) {
+	return m.edit(map[string]interface{}{"content": content})
+}
+
+func (m *messageQueryBuilder) SetEmbed(embed *disgord.Embed) (*disgord.Message, error) {
+	return m.edit(map[string]interface{}{"embed": embed})
+}
+
+func (m *messageQueryBuilder) Reaction(emoji interface{}) disgord.ReactionQueryBuilder {
+	return &reactionQueryBuilder{s: m.s, cid: m.cid, mid: m.mid, emoji: standardiseEmoji(emoji)}
+}
+
+type reactionQueryBuilder struct {
+	disgord.ReactionQueryBuilder
+	s     *Session
+	cid   disgord.Snowflake
+	mid   disgord.Snowflake
+	emoji string
+}
+
+func (r *reactionQueryBuilder) WithContext(context.Context) disgord.ReactionQueryBuilder {
+	return r
+}
+
+func (r *reactionQueryBuilder) Create(...disgord.Flag) error {
+	r.s.lock.Lock()
+	if r.s.getMessage(r.cid, r.mid) == nil {
+		r.s.lock.Unlock()
+		return notFound("message")
+	}
+	r.s.reactions[r.mid] = append(r.s.reactions[r.mid], Reaction{Emoji: r.emoji, UserID: r.s.BotUser.ID})
+	r.s.lock.Unlock()
+	return nil
+}
+
+// Removes the reaction from the user specified.
+func (r *reactionQueryBuilder) remove(UserID disgord.Snowflake) error {
+	r.s.lock.Lock()
+	defer r.s.lock.Unlock()
+	if r.s.getMessage(r.cid, r.mid) == nil {
+		return notFound("message")
+	}
+	reactions := r.s.reactions[r.mid]
+	for i, v := range reactions {
+		if v.UserID == UserID && v.Emoji == r.emoji {
+			r.s.reactions[r.mid] = append(reactions[:i:i], reactions[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *reactionQueryBuilder) DeleteOwn(...disgord.Flag) error {
+	return r.remove(r.s.BotUser.ID)
+}
+
+func (r *reactionQueryBuilder) DeleteUser(UserID disgord.Snowflake, _ ...disgord.Flag) error {
+	return r.remove(UserID)
+}
@@ -0,0 +1,308 @@
+package gommandtest
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Used when something which does not exist is requested, similarly to a 404 from Discord.
+func notFound(item string) error {
+	return &disgord.ErrRest{Code: 404, Msg: "Unknown " + item + "."}
+}
+
+// Reaction represents a reaction added to a message.
+type Reaction struct {
+	Emoji  string
+	UserID disgord.Snowflake
+}
+
+// Logger is a disgord logger which keeps everything logged in memory.
+type Logger struct {
+	lock   sync.Mutex
+	errors []interface{}
+}
+
+// Debug is used to log debug information. This is discarded.
+func (l *Logger) Debug(...interface{}) {}
+
+// Info is used to log information. This is discarded.
+func (l *Logger) Info(...interface{}) {}
+
+// Error is used to log an error. This is kept so it can be checked in tests.
+func (l *Logger) Error(v ...interface{}) {
+	l.lock.Lock()
+	l.errors = append(l.errors, v...)
+	l.lock.Unlock()
+}
+
+// Errors is used to get all of the errors which have been logged.
+func (l *Logger) Errors() []interface{} {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]interface{}{}, l.errors...)
+}
+
+// Defines a handler registered with the fake gateway.
+type handlerSpec struct {
+	middlewares []disgord.Middleware
+	ctrl        disgord.HandlerCtrl
+	handler     func(evt interface{})
+}
+
+// Session is an in-memory fake of disgord.Session which can be used to test commands without a connection to Discord.
+// Seed the session with the Add functions, then check what the bot did with the functions to get messages and reactions.
+// Any part of the session which is not faked will panic when used.
+type Session struct {
+	disgord.Session
+
+	lock      sync.RWMutex
+	logger    *Logger
+	nextID    disgord.Snowflake
+	guilds    map[disgord.Snowflake]*disgord.Guild
+	roles     map[disgord.Snowflake][]*disgord.Role
+	members   map[disgord.Snowflake]map[disgord.Snowflake]*disgord.Member
+	users     map[disgord.Snowflake]*disgord.User
+	channels  map[disgord.Snowflake]*disgord.Channel
+	messages  map[disgord.Snowflake][]*disgord.Message
+	reactions map[disgord.Snowflake][]Reaction
+	deleted   map[disgord.Snowflake]bool
+
+	// BotUser is the user which the bot is logged in as.
+	BotUser *disgord.User
+
+	handlersLock sync.Mutex
+	handlers     map[string][]*handlerSpec
+
+	restOnce sync.Once
+	rest     *disgord.Client
+}
+
+// NewSession is used to create a new fake session for the bot user specified.
+func NewSession(BotUser *disgord.User) *Session {
+	s := &Session{
+		logger:    &Logger{},
+		nextID:    1000000,
+		guilds:    map[disgord.Snowflake]*disgord.Guild{},
+		roles:     map[disgord.Snowflake][]*disgord.Role{},
+		members:   map[disgord.Snowflake]map[disgord.Snowflake]*disgord.Member{},
+		users:     map[disgord.Snowflake]*disgord.User{},
+		channels:  map[disgord.Snowflake]*disgord.Channel{},
+		messages:  map[disgord.Snowflake][]*disgord.Message{},
+		reactions: map[disgord.Snowflake][]Reaction{},
+		deleted:   map[disgord.Snowflake]bool{},
+		BotUser:   BotUser,
+		handlers:  map[string][]*handlerSpec{},
+	}
+	s.AddUser(BotUser)
+	return s
+}
+
+// Gets a new unique ID. The session lock must be held.
+func (s *Session) newID() disgord.Snowflake {
+	s.nextID++
+	return s.nextID
+}
+
+// Logger is used to get the logger for the session.
+func (s *Session) Logger() disgord.Logger {
+	return s.logger
+}
+
+// Errors is used to get all of the errors which were logged through the session logger.
+func (s *Session) Errors() []interface{} {
+	return s.logger.Errors()
+}
+
+// AddUser is used to add a user to the session.
+func (s *Session) AddUser(User *disgord.User) {
+	s.lock.Lock()
+	s.users[User.ID] = User
+	s.lock.Unlock()
+}
+
+// AddGuild is used to add a guild to the session.
+func (s *Session) AddGuild(Guild *disgord.Guild) {
+	s.lock.Lock()
+	s.guilds[Guild.ID] = Guild
+	if s.members[Guild.ID] == nil {
+		s.members[Guild.ID] = map[disgord.Snowflake]*disgord.Member{}
+	}
+	s.lock.Unlock()
+}
+
+// AddRole is used to add a role to a guild.
+func (s *Session) AddRole(GuildID disgord.Snowflake, Role *disgord.Role) {
+	s.lock.Lock()
+	s.roles[GuildID] = append(s.roles[GuildID], Role)
+	s.lock.Unlock()
+}
+
+// AddMember is used to add a member to a guild. The user of the member is also added to the session.
+func (s *Session) AddMember(GuildID disgord.Snowflake, Member *disgord.Member) {
+	Member.GuildID = GuildID
+	if Member.User != nil {
+		Member.UserID = Member.User.ID
+		s.AddUser(Member.User)
+	}
+	s.lock.Lock()
+	if s.members[GuildID] == nil {
+		s.members[GuildID] = map[disgord.Snowflake]*disgord.Member{}
+	}
+	s.members[GuildID][Member.UserID] = Member
+	s.lock.Unlock()
+}
+
+// AddChannel is used to add a channel to the session.
+func (s *Session) AddChannel(Channel *disgord.Channel) {
+	s.lock.Lock()
+	s.channels[Channel.ID] = Channel
+	s.lock.Unlock()
+}
+
+// AddMessage is used to add an existing message to the session without it being counted as sent by the bot.
+func (s *Session) AddMessage(Message *disgord.Message) {
+	s.lock.Lock()
+	if Message.ID == 0 {
+		Message.ID = s.newID()
+	}
+	s.messages[Message.ChannelID] = append(s.messages[Message.ChannelID], Message)
+	s.lock.Unlock()
+}
+
+// Messages is used to get all of the messages in a channel which have not been deleted, in the order they were sent.
+func (s *Session) Messages(ChannelID disgord.Snowflake) []*disgord.Message {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	a := make([]*disgord.Message, 0, len(s.messages[ChannelID]))
+	for _, v := range s.messages[ChannelID] {
+		if !s.deleted[v.ID] {
+			a = append(a, disgord.DeepCopy(v).(*disgord.Message))
+		}
+	}
+	return a
+}
+
+// SentMessages is used to get all of the messages the bot sent in a channel which have not been deleted.
+func (s *Session) SentMessages(ChannelID disgord.Snowflake) []*disgord.Message {
+	a := make([]*disgord.Message, 0)
+	for _, v := range s.Messages(ChannelID) {
+		if v.Author != nil && v.Author.ID == s.BotUser.ID {
+			a = append(a, v)
+		}
+	}
+	return a
+}
+
+// LastMessage is used to get the last message the bot sent in a channel which has not been deleted. This is nil if there are none.
+func (s *Session) LastMessage(ChannelID disgord.Snowflake) *disgord.Message {
+	msgs := s.SentMessages(ChannelID)
+	if len(msgs) == 0 {
+		return nil
+	}
+	return msgs[len(msgs)-1]
+}
+
+// Message is used to get a message by its ID. This is nil if it does not exist or was deleted.
+func (s *Session) Message(ChannelID, MessageID disgord.Snowflake) *disgord.Message {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	msg := s.getMessage(ChannelID, MessageID)
+	if msg == nil {
+		return nil
+	}
+	return disgord.DeepCopy(msg).(*disgord.Message)
+}
+
+// Gets a message. The session lock must be held.
+func (s *Session) getMessage(ChannelID, MessageID disgord.Snowflake) *disgord.Message {
+	if s.deleted[MessageID] {
+		return nil
+	}
+	for _, v := range s.messages[ChannelID] {
+		if v.ID == MessageID {
+			return v
+		}
+	}
+	return nil
+}
+
+// Deleted is used to check if a message was deleted.
+func (s *Session) Deleted(MessageID disgord.Snowflake) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.deleted[MessageID]
+}
+
+// Reactions is used to get all of the reactions on a message.
+func (s *Session) Reactions(MessageID disgord.Snowflake) []Reaction {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return append([]Reaction{}, s.reactions[MessageID]...)
+}
+
+// SendMsg is used to send a message as the bot user.
+func (s *Session) SendMsg(ChannelID disgord.Snowflake, data ...interface{}) (*disgord.Message, error) {
+	msg := &disgord.Message{ChannelID: ChannelID, Author: s.BotUser, Type: disgord.MessageTypeDefault}
+	for _, v := range data {
+		switch x := v.(type) {
+		case string:
+			if msg.Content == "" {
+				msg.Content = x
+			} else {
+				msg.Content += " " + x
+			}
+		case disgord.Embed:
+			msg.Embeds = append(msg.Embeds, &x)
+		case *disgord.Embed:
+			msg.Embeds = append(msg.Embeds, disgord.DeepCopy(x).(*disgord.Embed))
+		case disgord.CreateMessageParams:
+			msg.Content = x.Content
+			if x.Embed != nil {
+				msg.Embeds = append(msg.Embeds, x.Embed)
+			}
+		case *disgord.CreateMessageParams:
+			msg.Content = x.Content
+			if x.Embed != nil {
+				msg.Embeds = append(msg.Embeds, x.Embed)
+			}
+		case disgord.Message:
+			msg.Content = x.Content
+			msg.Embeds = append(msg.Embeds, x.Embeds...)
+		case *disgord.Message:
+			msg.Content = x.Content
+			msg.Embeds = append(msg.Embeds, x.Embeds...)
+		}
+	}
+	if len(msg.Embeds) > 1 {
+		return nil, errors.New("can only send one embed")
+	}
+	if msg.Content == "" && len(msg.Embeds) == 0 {
+		return nil, &disgord.ErrRest{Code: 400, Msg: "Cannot send an empty message."}
+	}
+	s.lock.Lock()
+	if _, ok := s.channels[ChannelID]; !ok {
+		s.lock.Unlock()
+		return nil, notFound("channel")
+	}
+	msg.ID = s.newID()
+	msg.GuildID = s.channels[ChannelID].GuildID
+	s.messages[ChannelID] = append(s.messages[ChannelID], msg)
+	s.lock.Unlock()
+	return disgord.DeepCopy(msg).(*disgord.Message), nil
+}
+
+// AddReaction is used to add a reaction from a user to a message and dispatch the reaction add event.
+// Note that the gommand menu handler runs in a new goroutine, so the result may not be visible straight away.
+func (s *Session) AddReaction(ChannelID, MessageID, UserID disgord.Snowflake, Emoji string) {
+	s.lock.Lock()
+	s.reactions[MessageID] = append(s.reactions[MessageID], Reaction{Emoji: Emoji, UserID: UserID})
+	s.lock.Unlock()
+	s.Dispatch(disgord.EvtMessageReactionAdd, &disgord.MessageReactionAdd{
+		UserID:       UserID,
+		ChannelID:    ChannelID,
+		MessageID:    MessageID,
+		PartialEmoji: &disgord.Emoji{Name: Emoji},
+	})
+}
@@ -0,0 +1,45 @@
+package gommand_test
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/auttaja/gommand"
+	"github.com/auttaja/gommand/gommandtest"
+)
+
+// Creates a session with a guild (10) owned by user 2 which has the channels 30 and 31, a moderator (3) and a user (4).
+func seededSession() *gommandtest.Session {
+	s := gommandtest.NewSession(&disgord.User{ID: 1, Username: "bot", Bot: true})
+	s.AddGuild(&disgord.Guild{ID: 10, OwnerID: 2})
+	s.AddRole(10, &disgord.Role{ID: 20, Permissions: disgord.PermissionBanMembers})
+	s.AddRole(10, &disgord.Role{ID: 21, Permissions: disgord.PermissionAdministrator})
+	s.AddChannel(&disgord.Channel{ID: 30, GuildID: 10})
+	s.AddChannel(&disgord.Channel{ID: 31, GuildID: 10})
+	s.AddMember(10, &disgord.Member{User: s.BotUser, Roles: []disgord.Snowflake{21}})
+	s.AddMember(10, &disgord.Member{User: &disgord.User{ID: 3, Username: "moderator"}, Roles: []disgord.Snowflake{20}})
+	s.AddMember(10, &disgord.Member{User: &disgord.User{ID: 4, Username: "user"}})
+	return s
+}
+
+// Creates a router which replies with any errors, along with a harness using the seeded session. If the prefix check is not set, the prefix is "%".
+func newTestHarness(Config *gommand.RouterConfig) (*gommand.Router, *gommandtest.Harness) {
+	if Config.PrefixCheck == nil {
+		Config.PrefixCheck = gommand.StaticPrefix("%")
+	}
+	r := gommand.NewRouter(Config)
+	r.AddErrorHandler(func(ctx *gommand.Context, err error) bool {
+		_, _ = ctx.Reply(err.Error())
+		return true
+	})
+	return r, gommandtest.NewHarness(r, seededSession())
+}
+
+// Creates a command which replies with the text.
+func replyCommand(Name, Text string) *gommand.Command {
+	return &gommand.Command{
+		Name: Name,
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Reply(Text)
+			return err
+		},
+	}
+}
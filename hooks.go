@@ -0,0 +1,120 @@
+package gommand
+
+import "time"
+
+// Hooks is used to define the interface which is used to observe what the router does while processing a message.
+// Hooks are called synchronously, so you should not block within them. Embed NopHooks if you only want to implement some of the functions.
+type Hooks interface {
+	// PrefixMatched is called when the message starts with the prefix.
+	PrefixMatched(ctx *Context)
+
+	// CommandResolved is called when the command being ran is found. For command groups, this is called for the group and then the sub-command.
+	CommandResolved(ctx *Context, cmd CommandInterface)
+
+	// PermissionDenied is called when the user does not have permission to run the command.
+	PermissionDenied(ctx *Context, cmd CommandInterface, err *IncorrectPermissions)
+
+	// CooldownHit is called when the command is on cooldown.
+	CooldownHit(ctx *Context, cmd CommandInterface, err *CommandOnCooldown)
+
+	// ArgsParsed is called when the arguments have been transformed. They can be found in ctx.Args.
+	ArgsParsed(ctx *Context, cmd CommandInterface)
+
+	// CommandStarted is called right before the command function is called.
+	CommandStarted(ctx *Context, cmd CommandInterface)
+
+	// CommandFinished is called when the command function returns or panics, with how long it ran for and the error it returned.
+	// This is also called with the error if middleware or the arguments stop the command function from running (in which case CommandStarted is not called).
+	CommandFinished(ctx *Context, cmd CommandInterface, duration time.Duration, err error)
+}
+
+// NopHooks implements the Hooks interface and does nothing. This can be embedded in your own hooks.
+type NopHooks struct{}
+
+// PrefixMatched does nothing.
+func (NopHooks) PrefixMatched(*Context) {}
+
+// CommandResolved does nothing.
+func (NopHooks) CommandResolved(*Context, CommandInterface) {}
+
+// PermissionDenied does nothing.
+func (NopHooks) PermissionDenied(*Context, CommandInterface, *IncorrectPermissions) {}
+
+// CooldownHit does nothing.
+func (NopHooks) CooldownHit(*Context, CommandInterface, *CommandOnCooldown) {}
+
+// ArgsParsed does nothing.
+func (NopHooks) ArgsParsed(*Context, CommandInterface) {}
+
+// CommandStarted does nothing.
+func (NopHooks) CommandStarted(*Context, CommandInterface) {}
+
+// CommandFinished does nothing.
+func (NopHooks) CommandFinished(*Context, CommandInterface, time.Duration, error) {}
+
+// Handles multiple hooks.
+type multiHooksHandler struct {
+	hooks []Hooks
+}
+
+// PrefixMatched is used to call PrefixMatched on all hooks.
+func (m *multiHooksHandler) PrefixMatched(ctx *Context) {
+	for _, v := range m.hooks {
+		v.PrefixMatched(ctx)
+	}
+}
+
+// CommandResolved is used to call CommandResolved on all hooks.
+func (m *multiHooksHandler) CommandResolved(ctx *Context, cmd CommandInterface) {
+	for _, v := range m.hooks {
+		v.CommandResolved(ctx, cmd)
+	}
+}
+
+// PermissionDenied is used to call PermissionDenied on all hooks.
+func (m *multiHooksHandler) PermissionDenied(ctx *Context, cmd CommandInterface, err *IncorrectPermissions) {
+	for _, v := range m.hooks {
+		v.PermissionDenied(ctx, cmd, err)
+	}
+}
+
+// CooldownHit is used to call CooldownHit on all hooks.
+func (m *multiHooksHandler) CooldownHit(ctx *Context, cmd CommandInterface, err *CommandOnCooldown) {
+	for _, v := range m.hooks {
+		v.CooldownHit(ctx, cmd, err)
+	}
+}
+
+// ArgsParsed is used to call ArgsParsed on all hooks.
+func (m *multiHooksHandler) ArgsParsed(ctx *Context, cmd CommandInterface) {
+	for _, v := range m.hooks {
+		v.ArgsParsed(ctx, cmd)
+	}
+}
+
+// CommandStarted is used to call CommandStarted on all hooks.
+func (m *multiHooksHandler) CommandStarted(ctx *Context, cmd CommandInterface) {
+	for _, v := range m.hooks {
+		v.CommandStarted(ctx, cmd)
+	}
+}
+
+// CommandFinished is used to call CommandFinished on all hooks.
+func (m *multiHooksHandler) CommandFinished(ctx *Context, cmd CommandInterface, duration time.Duration, err error) {
+	for _, v := range m.hooks {
+		v.CommandFinished(ctx, cmd, duration, err)
+	}
+}
+
+// MultipleHooks is used to chain multiple hooks together. They are called in the order they are given.
+func MultipleHooks(hooks ...Hooks) Hooks {
+	return &multiHooksHandler{hooks: hooks}
+}
+
+// Gets the hooks which the router uses. This is safe to call on a nil router.
+func (r *Router) hooks() Hooks {
+	if r == nil || r.Hooks == nil {
+		return NopHooks{}
+	}
+	return r.Hooks
+}
@@ -0,0 +1,164 @@
+package gommand
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Records the hooks which were called.
+type recordingHooks struct {
+	NopHooks
+	lock   sync.Mutex
+	events []string
+}
+
+func (r *recordingHooks) add(event string) {
+	r.lock.Lock()
+	r.events = append(r.events, event)
+	r.lock.Unlock()
+}
+
+func (r *recordingHooks) PrefixMatched(*Context) { r.add("prefix") }
+func (r *recordingHooks) CommandResolved(_ *Context, cmd CommandInterface) {
+	r.add("resolved " + cmd.GetName())
+}
+func (r *recordingHooks) PermissionDenied(_ *Context, cmd CommandInterface, _ *IncorrectPermissions) {
+	r.add("denied " + cmd.GetName())
+}
+func (r *recordingHooks) CooldownHit(_ *Context, cmd CommandInterface, _ *CommandOnCooldown) {
+	r.add("cooldown " + cmd.GetName())
+}
+func (r *recordingHooks) ArgsParsed(ctx *Context, cmd CommandInterface) {
+	r.add("args " + cmd.GetName())
+}
+func (r *recordingHooks) CommandStarted(_ *Context, cmd CommandInterface) {
+	r.add("started " + cmd.GetName())
+}
+func (r *recordingHooks) CommandFinished(_ *Context, cmd CommandInterface, _ time.Duration, err error) {
+	if err == nil {
+		r.add("finished " + cmd.GetName())
+	} else {
+		r.add("finished " + cmd.GetName() + " " + err.Error())
+	}
+}
+
+// Gets the events and clears them.
+func (r *recordingHooks) flush() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	x := strings.Join(r.events, ", ")
+	r.events = nil
+	return x
+}
+
+// TestHooks is used to test the lifecycle hooks and the Prometheus hooks.
+func TestHooks(t *testing.T) {
+	recorder := &recordingHooks{}
+	metrics := NewPrometheusHooks()
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Hooks:       MultipleHooks(recorder, metrics),
+	})
+	r.AddErrorHandler(func(*Context, error) bool {
+		return true
+	})
+	r.SetCommand(&Command{
+		Name: "ok",
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	r.SetCommand(&Command{
+		Name: "denied",
+		PermissionValidators: []PermissionValidator{func(ctx *Context) (string, bool) {
+			return "No.", false
+		}},
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	r.SetCommand(&Command{
+		Name:     "cool",
+		Cooldown: &UserCooldown{MaxRuns: 1, UsageExpires: time.Hour},
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	r.SetCommand(&Command{
+		Name: "fail",
+		Function: func(ctx *Context) error {
+			return errors.New("failed")
+		},
+	})
+	r.SetCommand(&Command{
+		Name: "panic",
+		Function: func(ctx *Context) error {
+			panic("oh no")
+		},
+	})
+
+	r.SetCommand(&Command{
+		Name:            "args",
+		ArgTransformers: []ArgTransformer{{Function: IntTransformer}},
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	r.SetCommand(&Command{
+		Name: "middleware",
+		Middleware: []Middleware{func(ctx *Context) error {
+			return errors.New("blocked")
+		}},
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+
+	tests := []struct {
+		content string
+		events  string
+	}{
+		{"ok", ""},
+		{"%ok", "prefix, resolved ok, args ok, started ok, finished ok"},
+		{"%denied", "prefix, resolved denied, denied denied"},
+		{"%cool", "prefix, resolved cool, args cool, started cool, finished cool"},
+		{"%cool", "prefix, resolved cool, cooldown cool"},
+		{"%fail", "prefix, resolved fail, args fail, started fail, finished fail failed"},
+		{"%panic", "prefix, resolved panic, args panic, started panic, finished panic oh no"},
+		{"%args", "prefix, resolved args, finished args " + DefaultMessages[MessageArgumentMissing]},
+		{"%middleware", "prefix, resolved middleware, finished middleware blocked"},
+	}
+	for _, v := range tests {
+		r.CommandProcessor(nil, 0, mockMessage(v.content), true)
+		if events := recorder.flush(); events != v.events {
+			t.Fatalf("%s: expected %q, got %q", v.content, v.events, events)
+		}
+	}
+
+	// Check the metrics.
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	for _, v := range []string{
+		"gommand_prefix_matches_total 8\n",
+		`gommand_commands_resolved_total{command="cool"} 2` + "\n",
+		`gommand_permission_denied_total{command="denied"} 1` + "\n",
+		`gommand_cooldown_hits_total{command="cool"} 1` + "\n",
+		`gommand_commands_total{command="ok",result="success"} 1` + "\n",
+		`gommand_commands_total{command="fail",result="error"} 1` + "\n",
+		`gommand_command_errors_total{command="fail",class="errorString"} 1` + "\n",
+		`gommand_command_errors_total{command="panic",class="PanicError"} 1` + "\n",
+		`gommand_command_errors_total{command="args",class="InvalidArgCount"} 1` + "\n",
+		`gommand_commands_total{command="middleware",result="error"} 1` + "\n",
+		`gommand_command_duration_seconds_bucket{command="ok",le="+Inf"} 1` + "\n",
+		`gommand_command_duration_seconds_count{command="ok"} 1` + "\n",
+	} {
+		if !strings.Contains(body, v) {
+			t.Fatalf("expected the metrics to contain %q, got:\n%s", v, body)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package gommand
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hako/durafmt"
+)
+
+// MessageID is the ID of a built-in message which can be translated.
+type MessageID string
+
+// Defines the IDs of the built-in messages. Parameters are shown in the default messages within curly brackets (such as "{duration}").
+const (
+	MessageCooldown                 MessageID = "cooldown"
+	MessageInvalidInteger           MessageID = "transformer.integer"
+	MessageInvalidUnsignedInteger   MessageID = "transformer.unsigned_integer"
+	MessageInvalidUser              MessageID = "transformer.user"
+	MessageInvalidMember            MessageID = "transformer.member"
+	MessageInvalidChannel           MessageID = "transformer.channel"
+	MessageInvalidGuild             MessageID = "transformer.guild"
+	MessageInvalidMessageURL        MessageID = "transformer.message_url"
+	MessageInvalidBoolean           MessageID = "transformer.boolean"
+	MessageInvalidRole              MessageID = "transformer.role"
+	MessageInvalidDuration          MessageID = "transformer.duration"
+	MessageInvalidArgument          MessageID = "transformer.any"
+	MessageGreedyArgumentMissing    MessageID = "args.greedy_missing"
+	MessageArgumentMissing          MessageID = "args.missing"
+	MessageCommandBlank             MessageID = "command.blank"
+	MessageCommandNotExists         MessageID = "command.not_exists"
+	MessageGroupCommandBlank        MessageID = "group.blank"
+	MessageGroupCommandNotFound     MessageID = "group.not_found"
+	MessageMemberPermissionMissing  MessageID = "permissions.member"
+	MessageBotPermissionMissing     MessageID = "permissions.bot"
+	MessageBotOwnerOnly             MessageID = "permissions.bot_owner"
+	MessagePromptTimeout            MessageID = "prompt.timeout"
+	MessagePromptCancelled          MessageID = "prompt.cancelled"
+	MessagePromptTryAgain           MessageID = "prompt.try_again"
+	MessagePromptYesNo              MessageID = "prompt.yes_no"
+	MessagePromptInvalidChoice      MessageID = "prompt.invalid_choice"
+	MessageMenuLoading              MessageID = "menu.loading"
+	MessageMenuBack                 MessageID = "menu.back"
+	MessageMenuBackDescription      MessageID = "menu.back_description"
+	MessageMenuExit                 MessageID = "menu.exit"
+	MessageMenuExitDescription      MessageID = "menu.exit_description"
+	MessagePageFooter               MessageID = "paginator.footer"
+	MessagePageFooterUnknownCount   MessageID = "paginator.footer_unknown_count"
+	MessagePageBack                 MessageID = "paginator.back"
+	MessagePageBackDescription      MessageID = "paginator.back_description"
+	MessagePageForward              MessageID = "paginator.forward"
+	MessagePageForwardDescription   MessageID = "paginator.forward_description"
+	MessagePageJump                 MessageID = "paginator.jump"
+	MessagePageJumpDescription      MessageID = "paginator.jump_description"
+	MessagePageJumpQuestion         MessageID = "paginator.jump_question"
+	MessageHelpGeneralCommands      MessageID = "help.general_commands"
+	MessageHelpGeneralDescription   MessageID = "help.general_description"
+	MessageHelpNoDescription        MessageID = "help.no_description"
+	MessageHelpNoPermission         MessageID = "help.no_permission"
+	MessageHelpNotFoundTitle        MessageID = "help.not_found_title"
+	MessageHelpNotFound             MessageID = "help.not_found"
+	MessageHelpPages                MessageID = "help.pages"
+	MessageCommandsReloaded         MessageID = "developer.reloaded"
+	MessageCooldownsCleared         MessageID = "developer.cooldowns_cleared"
+	MessageRouterStats              MessageID = "developer.router_stats"
+	MessageStatsTitle               MessageID = "stats.title"
+	MessageStatsCommandTitle        MessageID = "stats.command_title"
+	MessageStatsEntry               MessageID = "stats.entry"
+	MessageStatsNoUsage             MessageID = "stats.no_usage"
+	MessageShortcutAdded            MessageID = "shortcut.added"
+	MessageShortcutRemoved          MessageID = "shortcut.removed"
+	MessageShortcutNotFound         MessageID = "shortcut.not_found"
+	MessageShortcutCommandConflict  MessageID = "shortcut.command_conflict"
+	MessageShortcutNone             MessageID = "shortcut.none"
+	MessageShortcutDescription      MessageID = "shortcut.description"
+	MessageCustomCommandAdded       MessageID = "custom_command.added"
+	MessageCustomCommandEdited      MessageID = "custom_command.edited"
+	MessageCustomCommandRemoved     MessageID = "custom_command.removed"
+	MessageCustomCommandNotFound    MessageID = "custom_command.not_found"
+	MessageCustomCommandExists      MessageID = "custom_command.exists"
+	MessageCustomCommandConflict    MessageID = "custom_command.conflict"
+	MessageCustomCommandLimit       MessageID = "custom_command.limit"
+	MessageCustomCommandTooLong     MessageID = "custom_command.too_long"
+	MessageCustomCommandNone        MessageID = "custom_command.none"
+	MessageCustomCommandNameTooLong MessageID = "custom_command.name_too_long"
+	MessageCustomCommandListMore    MessageID = "custom_command.list_more"
+	MessageChainTooLong             MessageID = "chain.too_long"
+	MessageCommandCannotProduceText MessageID = "chain.cannot_produce_text"
+	MessageCommandCannotConsumeText MessageID = "chain.cannot_consume_text"
+	MessageHelpShortcuts            MessageID = "help.shortcuts"
+	MessageHelpShortcutsDescription MessageID = "help.shortcuts_description"
+	MessageDurationYear             MessageID = "duration.year"
+	MessageDurationYears            MessageID = "duration.years"
+	MessageDurationWeek             MessageID = "duration.week"
+	MessageDurationWeeks            MessageID = "duration.weeks"
+	MessageDurationDay              MessageID = "duration.day"
+	MessageDurationDays             MessageID = "duration.days"
+	MessageDurationHour             MessageID = "duration.hour"
+	MessageDurationHours            MessageID = "duration.hours"
+	MessageDurationMinute           MessageID = "duration.minute"
+	MessageDurationMinutes          MessageID = "duration.minutes"
+	MessageDurationSecond           MessageID = "duration.second"
+	MessageDurationSeconds          MessageID = "duration.seconds"
+	MessageDurationMillisecond      MessageID = "duration.millisecond"
+	MessageDurationMilliseconds     MessageID = "duration.milliseconds"
+	MessageDurationMicrosecond      MessageID = "duration.microsecond"
+	MessageDurationMicroseconds     MessageID = "duration.microseconds"
+)
+
+// DefaultMessages is the built-in messages in English. These are used when there is no translation for a message.
+var DefaultMessages = map[MessageID]string{
+	MessageCooldown:                 "This command has a {duration} cooldown.",
+	MessageInvalidInteger:           "Could not transform the argument to an integer.",
+	MessageInvalidUnsignedInteger:   "Could not transform the argument to an unsigned integer.",
+	MessageInvalidUser:              "This was not a valid user ID or mention.",
+	MessageInvalidMember:            "This was not a valid user ID or mention of someone in this guild.",
+	MessageInvalidChannel:           "This was not a valid channel ID or mention of a channel in this guild.",
+	MessageInvalidGuild:             "This was not a valid guild ID.",
+	MessageInvalidMessageURL:        "This is not a valid message URL or a message which the bot cannot access.",
+	MessageInvalidBoolean:           "This is not a valid boolean representation.",
+	MessageInvalidRole:              "This was not a valid role ID, mention or name of a role in this guild.",
+	MessageInvalidDuration:          "This was not a valid duration.",
+	MessageInvalidArgument:          "Unable to transform the argument properly.",
+	MessageGreedyArgumentMissing:    "Expected an argument for the greedy converter.",
+	MessageArgumentMissing:          "A required argument is missing.",
+	MessageCommandBlank:             "The command is blank.",
+	MessageCommandNotExists:         "The command \"{command}\" does not exist.",
+	MessageGroupCommandBlank:        "This group expects a command but none was given.",
+	MessageGroupCommandNotFound:     "The command specified for the group was not found.",
+	MessageMemberPermissionMissing:  "You must have the \"{permission}\" permission to run this command.",
+	MessageBotPermissionMissing:     "The bot must have the \"{permission}\" permission to run this command.",
+	MessageBotOwnerOnly:             "This command can only be used by the bot owners.",
+	MessagePromptTimeout:            "The prompt timed out.",
+	MessagePromptCancelled:          "The prompt was cancelled.",
+	MessagePromptTryAgain:           "{error} Please try again.",
+	MessagePromptYesNo:              "{question} (yes/no)",
+	MessagePromptInvalidChoice:      "This is not one of the choices.",
+	MessageMenuLoading:              "Loading...",
+	MessageMenuBack:                 "Back",
+	MessageMenuBackDescription:      "Goes back to the parent menu.",
+	MessageMenuExit:                 "Exit",
+	MessageMenuExitDescription:      "Exits the current menu.",
+	MessagePageFooter:               "Page {page}/{pages}",
+	MessagePageFooterUnknownCount:   "Page {page}",
+	MessagePageBack:                 "Back",
+	MessagePageBackDescription:      "Goes back a page.",
+	MessagePageForward:              "Forward",
+	MessagePageForwardDescription:   "Goes forward a page.",
+	MessagePageJump:                 "Jump",
+	MessagePageJumpDescription:      "Jumps to a page number which you type.",
+	MessagePageJumpQuestion:         "Which page would you like to go to?",
+	MessageHelpGeneralCommands:      "General Commands",
+	MessageHelpGeneralDescription:   "These commands have not been assigned a category yet.",
+	MessageHelpNoDescription:        "No description set.",
+	MessageHelpNoPermission:         "**You do not have permission to run this.**",
+	MessageHelpNotFoundTitle:        "Command not found:",
+	MessageHelpNotFound:             "The command \"{command}\" was not found.",
+	MessageHelpPages:                "Use {prefix}help <page number> to flick between pages.",
+	MessageCommandsReloaded:         "Reloaded the commands.",
+	MessageCooldownsCleared:         "Cleared {count} cooldown(s).",
+	MessageRouterStats:              "**Router Stats**\nCommands: {commands}\nCategories: {categories}\nShards: {shards}\nGoroutines: {goroutines}\nMemory: {memory} MB\nUptime: {uptime}",
+	MessageStatsTitle:               "Command usage in the last {period}",
+	MessageStatsCommandTitle:        "Usage of {command} in the last {period}",
+	MessageStatsEntry:               "{uses} use(s), {errors} error(s)",
+	MessageStatsNoUsage:             "There has been no usage in the last {period}.",
+	MessageShortcutAdded:            "Added the shortcut \"{name}\".",
+	MessageShortcutRemoved:          "Removed the shortcut \"{name}\".",
+	MessageShortcutNotFound:         "The shortcut \"{name}\" does not exist.",
+	MessageShortcutCommandConflict:  "There is already a command called \"{name}\".",
+	MessageShortcutNone:             "This guild does not have any shortcuts.",
+	MessageShortcutDescription:      "Runs `{command}`.",
+	MessageCustomCommandAdded:       "Added the custom command \"{name}\".",
+	MessageCustomCommandEdited:      "Edited the custom command \"{name}\".",
+	MessageCustomCommandRemoved:     "Removed the custom command \"{name}\".",
+	MessageCustomCommandNotFound:    "The custom command \"{name}\" does not exist.",
+	MessageCustomCommandExists:      "The custom command \"{name}\" already exists. Use the edit command to change it.",
+	MessageCustomCommandConflict:    "There is already a command or shortcut called \"{name}\".",
+	MessageCustomCommandLimit:       "This guild has reached the limit of {max} custom commands.",
+	MessageCustomCommandTooLong:     "Custom commands cannot be longer than {max} characters.",
+	MessageCustomCommandNone:        "This guild does not have any custom commands.",
+	MessageCustomCommandNameTooLong: "Custom command names cannot be longer than {max} characters.",
+	MessageCustomCommandListMore:    "and {count} more",
+	MessageChainTooLong:             "You can only run up to {max} commands in one message.",
+	MessageCommandCannotProduceText: "The output of the command \"{command}\" cannot be piped into another command.",
+	MessageCommandCannotConsumeText: "The command \"{command}\" cannot have text piped into it.",
+	MessageHelpShortcuts:            "Guild shortcuts",
+	MessageHelpShortcutsDescription: "These shortcuts have been added by the admins of this guild.",
+	MessageDurationYear:             "year",
+	MessageDurationYears:            "years",
+	MessageDurationWeek:             "week",
+	MessageDurationWeeks:            "weeks",
+	MessageDurationDay:              "day",
+	MessageDurationDays:             "days",
+	MessageDurationHour:             "hour",
+	MessageDurationHours:            "hours",
+	MessageDurationMinute:           "minute",
+	MessageDurationMinutes:          "minutes",
+	MessageDurationSecond:           "second",
+	MessageDurationSeconds:          "seconds",
+	MessageDurationMillisecond:      "millisecond",
+	MessageDurationMilliseconds:     "milliseconds",
+	MessageDurationMicrosecond:      "microsecond",
+	MessageDurationMicroseconds:     "microseconds",
+}
+
+// Catalog is used to get the translations of the built-in messages.
+type Catalog interface {
+	// GetMessage is used to get the message in the locale. ok should be false if there is no translation.
+	GetMessage(Locale string, ID MessageID) (message string, ok bool)
+}
+
+// MapCatalog is a catalog which maps locales (such as "fr" or "pt-BR") to the translated messages.
+type MapCatalog map[string]map[MessageID]string
+
+// GetMessage is used to get the message in the locale.
+func (m MapCatalog) GetMessage(Locale string, ID MessageID) (string, bool) {
+	msg, ok := m[Locale][ID]
+	return msg, ok
+}
+
+// LocaleResolver is used to get the locale of the command invocation, such as from a guild setting or the locale of the user.
+// An empty string means the default messages are used.
+type LocaleResolver = func(ctx *Context) string
+
+// Translate is used to get a message in the locale, replacing the parameters in the message.
+// Params are pairs of names and values, for example Translate("fr", MessageCooldown, "duration", "1 minute").
+// If the catalog does not have the message in the locale (or the language of the locale, such as "pt" for "pt-BR"), the default message is used.
+func (r *Router) Translate(Locale string, ID MessageID, Params ...string) string {
+	msg, ok := "", false
+	if r != nil && r.Catalog != nil && Locale != "" {
+		msg, ok = r.Catalog.GetMessage(Locale, ID)
+		if lang := localeLanguage(Locale); !ok && lang != "" {
+			msg, ok = r.Catalog.GetMessage(lang, ID)
+		}
+	}
+	if !ok {
+		if msg, ok = DefaultMessages[ID]; !ok {
+			msg = string(ID)
+		}
+	}
+	for i := 0; i+1 < len(Params); i += 2 {
+		msg = strings.Replace(msg, "{"+Params[i]+"}", Params[i+1], -1)
+	}
+	return msg
+}
+
+// Locale is used to get the locale of the command invocation using the routers LocaleResolver. This is resolved once and then cached.
+func (c *Context) Locale() string {
+	if c == nil || c.Router == nil || c.Router.LocaleResolver == nil {
+		return ""
+	}
+	if !c.localeResolved {
+		c.locale = c.Router.LocaleResolver(c)
+		c.localeResolved = true
+	}
+	return c.locale
+}
+
+// Translate is used to get a message in the locale of the command invocation. See Router.Translate for information about the parameters.
+func (c *Context) Translate(ID MessageID, Params ...string) string {
+	if c == nil {
+		return (*Router)(nil).Translate("", ID, Params...)
+	}
+	return c.Router.Translate(c.Locale(), ID, Params...)
+}
+
+// Maps the units used by durafmt to the message IDs.
+var durationUnits = map[string]MessageID{
+	"year":         MessageDurationYear,
+	"years":        MessageDurationYears,
+	"week":         MessageDurationWeek,
+	"weeks":        MessageDurationWeeks,
+	"day":          MessageDurationDay,
+	"days":         MessageDurationDays,
+	"hour":         MessageDurationHour,
+	"hours":        MessageDurationHours,
+	"minute":       MessageDurationMinute,
+	"minutes":      MessageDurationMinutes,
+	"second":       MessageDurationSecond,
+	"seconds":      MessageDurationSeconds,
+	"millisecond":  MessageDurationMillisecond,
+	"milliseconds": MessageDurationMilliseconds,
+	"microsecond":  MessageDurationMicrosecond,
+	"microseconds": MessageDurationMicroseconds,
+}
+
+// Translates the units in the output of durafmt.
+func localiseDuration(formatted string, translate func(ID MessageID) string) string {
+	parts := strings.Split(formatted, " ")
+	for i, v := range parts {
+		if id, ok := durationUnits[v]; ok {
+			parts[i] = translate(id)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatDuration is used to format a duration in a human readable way (such as "1 hour 30 minutes") in the locale of the command invocation.
+// LimitFirstN is the maximum number of units shown, 0 means there is no limit.
+func (c *Context) FormatDuration(Duration time.Duration, LimitFirstN int) string {
+	d := durafmt.Parse(Duration)
+	if LimitFirstN > 0 {
+		d = d.LimitFirstN(LimitFirstN)
+	}
+	return localiseDuration(d.String(), func(ID MessageID) string {
+		return c.Translate(ID)
+	})
+}
@@ -0,0 +1,152 @@
+package gommand
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTranslations is used to test translating the built-in messages.
+func TestTranslations(t *testing.T) {
+	locales := map[uint64]string{1: "fr-CA", 2: "de"}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Catalog: MapCatalog{
+			"fr": {
+				MessageCooldown:        "Cette commande a un temps de recharge de {duration}.",
+				MessageDurationMinute:  "minute",
+				MessageDurationSeconds: "secondes",
+			},
+		},
+		LocaleResolver: func(ctx *Context) string {
+			return locales[uint64(ctx.Message.GuildID)]
+		},
+	})
+	r.SetCommand(&Command{
+		Name:     "cmd",
+		Cooldown: &GuildCooldown{MaxRuns: 1, UsageExpires: time.Minute + 30*time.Second},
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	for _, v := range []struct {
+		guild    uint64
+		expected string
+	}{
+		{1, "Cette commande a un temps de recharge de 1 minute 30 secondes."},
+		{2, "This command has a 1 minute 30 seconds cooldown."},
+		{3, "This command has a 1 minute 30 seconds cooldown."},
+	} {
+		msg := mockMessage("%cmd")
+		msg.GuildID = 0
+		msg.GuildID.UnmarshalJSON([]byte(`"` + string(rune('0'+v.guild)) + `"`))
+		r.CommandProcessor(nil, 0, msg, true)
+		r.CommandProcessor(nil, 0, msg, true)
+		e, ok := lastErr.(*CommandOnCooldown)
+		if !ok || e.Message != v.expected {
+			t.Fatal("unexpected error:", lastErr)
+		}
+	}
+
+	// Check the parameters and falling back to the default messages.
+	if r.Translate("fr", MessageHelpNotFound, "command", "x") != "The command \"x\" was not found." {
+		t.Fatal("the default message should be used")
+	}
+	if (*Context)(nil).Translate(MessageMenuLoading) != "Loading..." || r.Translate("", "unknown") != "unknown" {
+		t.Fatal("unexpected message")
+	}
+}
+
+// TestLocalisedCommands is used to test running and getting help for commands by their names in other locales.
+func TestLocalisedCommands(t *testing.T) {
+	locales := map[uint64]string{1: "fr", 2: "pt-BR"}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Help:        &HelpConfig{TextFallback: true},
+		LocaleResolver: func(ctx *Context) string {
+			return locales[uint64(ctx.Message.GuildID)]
+		},
+	})
+	ran := ""
+	ping := &Command{
+		Name:        "ping",
+		Description: "Pings the bot.",
+		Localisations: map[string]*CommandLocalisation{
+			"fr": {Name: "sonner", Aliases: []string{"s"}, Description: "Sonne le bot."},
+			"pt": {Name: "pingar"},
+		},
+		Function: func(ctx *Context) error {
+			ran = "ping"
+			return nil
+		},
+	}
+	r.SetCommand(ping)
+	group := &CommandGroup{Name: "config", Localisations: map[string]*CommandLocalisation{"fr": {Name: "configuration"}}}
+	group.AddCommand(&Command{
+		Name:          "prefix",
+		Localisations: map[string]*CommandLocalisation{"fr": {Name: "préfixe"}},
+		Function: func(ctx *Context) error {
+			ran = ctx.CommandPath()
+			return nil
+		},
+	})
+	r.SetCommand(group)
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	run := func(guild uint64, content string) string {
+		ran = ""
+		lastErr = nil
+		msg := mockMessage(content)
+		msg.GuildID = 0
+		msg.GuildID.UnmarshalJSON([]byte(`"` + string(rune('0'+guild)) + `"`))
+		r.CommandProcessor(nil, 0, msg, true)
+		return ran
+	}
+	for _, v := range []struct {
+		guild    uint64
+		content  string
+		expected string
+	}{
+		{1, "%sonner", "ping"},
+		{1, "%S", "ping"},
+		{1, "%ping", "ping"},
+		{1, "%configuration préfixe", "config prefix"},
+		{1, "%config prefix", "config prefix"},
+		{2, "%pingar", "ping"},
+		{3, "%sonner", ""},
+		{2, "%configuration préfixe", ""},
+	} {
+		if res := run(v.guild, v.content); res != v.expected {
+			t.Fatal("unexpected result for", v.content, "in guild", v.guild, ":", res, lastErr)
+		}
+	}
+
+	// Check the help command lists the names in the locale.
+	ctx := &Context{Router: r, Message: mockMessage(""), Prefix: "%"}
+	ctx.locale, ctx.localeResolved = "fr", true
+	h := newHelpHandler(&HelpConfig{})
+	fields := h.createPages(ctx)[0].Fields
+	if fields[0].Name != "%configuration <préfixe>" || fields[2].Name != "%sonner " || fields[2].Value != "Sonne le bot." {
+		t.Fatal("unexpected help fields:", fields[0], fields[2])
+	}
+	embed := h.createCommandEmbed(ctx, []string{"configuration"})
+	if embed.Title != "%configuration <préfixe>" || len(embed.Fields) != 1 || embed.Fields[0].Name != "%configuration préfixe " {
+		t.Fatal("unexpected group help:", embed)
+	}
+	if LocalisedName(ping, "de") != "ping" || LocalisedDescription(ping, "pt-BR") != "Pings the bot." {
+		t.Fatal("the command should fall back to its own name and description")
+	}
+
+	// Check removing the command removes the localised names.
+	r.RemoveCommand(ping)
+	if r.GetLocalisedCommand("fr", "sonner") != nil {
+		t.Fatal("the localised name should be removed")
+	}
+}
@@ -0,0 +1,64 @@
+package gommand
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/andersfylling/disgord"
+)
+
+// InMemoryCustomCommandStorageAdapter is used to hold the guild custom commands in RAM.
+// Note that this will not survive a restart, so this is mainly useful for testing.
+type InMemoryCustomCommandStorageAdapter struct {
+	lock *sync.RWMutex
+	cmds map[disgord.Snowflake]map[string]*CustomCommand
+}
+
+// Init is used to initialise the in-memory custom command storage.
+func (m *InMemoryCustomCommandStorageAdapter) Init() {
+	m.lock = &sync.RWMutex{}
+	m.cmds = map[disgord.Snowflake]map[string]*CustomCommand{}
+}
+
+// Get is used to get a custom command by its lowercase name. If it doesn't exist, this will be nil.
+func (m *InMemoryCustomCommandStorageAdapter) Get(GuildID disgord.Snowflake, Name string) (*CustomCommand, error) {
+	m.lock.RLock()
+	c := m.cmds[GuildID][Name]
+	m.lock.RUnlock()
+	return c, nil
+}
+
+// Set is used to add or replace a custom command.
+func (m *InMemoryCustomCommandStorageAdapter) Set(GuildID disgord.Snowflake, Value *CustomCommand) error {
+	m.lock.Lock()
+	guild := m.cmds[GuildID]
+	if guild == nil {
+		guild = map[string]*CustomCommand{}
+		m.cmds[GuildID] = guild
+	}
+	guild[Value.Name] = Value
+	m.lock.Unlock()
+	return nil
+}
+
+// Delete is used to delete a custom command by its lowercase name.
+func (m *InMemoryCustomCommandStorageAdapter) Delete(GuildID disgord.Snowflake, Name string) error {
+	m.lock.Lock()
+	delete(m.cmds[GuildID], Name)
+	m.lock.Unlock()
+	return nil
+}
+
+// List is used to get all of the custom commands of a guild, ordered by their name.
+func (m *InMemoryCustomCommandStorageAdapter) List(GuildID disgord.Snowflake) ([]*CustomCommand, error) {
+	m.lock.RLock()
+	cmds := make([]*CustomCommand, 0, len(m.cmds[GuildID]))
+	for _, v := range m.cmds[GuildID] {
+		cmds = append(cmds, v)
+	}
+	m.lock.RUnlock()
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].Name < cmds[j].Name
+	})
+	return cmds, nil
+}
@@ -0,0 +1,41 @@
+package gommand
+
+import (
+	"github.com/andersfylling/disgord"
+	"sync"
+)
+
+// InMemoryMenuStorageAdapter is used to hold persistent menus in RAM.
+// Note that this will not survive a restart, so this is mainly useful for testing or when menus only need to be rebuilt from state.
+type InMemoryMenuStorageAdapter struct {
+	lock  *sync.RWMutex
+	menus map[disgord.Snowflake]*PersistentMenu
+}
+
+// Init is used to initialise the in-memory menu storage.
+func (m *InMemoryMenuStorageAdapter) Init() {
+	m.lock = &sync.RWMutex{}
+	m.menus = map[disgord.Snowflake]*PersistentMenu{}
+}
+
+// Get is used to get a persisted menu. If it doesn't exist, this will be nil.
+func (m *InMemoryMenuStorageAdapter) Get(MessageID disgord.Snowflake) *PersistentMenu {
+	m.lock.RLock()
+	menu := m.menus[MessageID]
+	m.lock.RUnlock()
+	return menu
+}
+
+// Set is used to persist a menu.
+func (m *InMemoryMenuStorageAdapter) Set(MessageID disgord.Snowflake, Menu *PersistentMenu) {
+	m.lock.Lock()
+	m.menus[MessageID] = Menu
+	m.lock.Unlock()
+}
+
+// Delete is used to delete a persisted menu.
+func (m *InMemoryMenuStorageAdapter) Delete(MessageID disgord.Snowflake) {
+	m.lock.Lock()
+	delete(m.menus, MessageID)
+	m.lock.Unlock()
+}
@@ -0,0 +1,64 @@
+package gommand
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/andersfylling/disgord"
+)
+
+// InMemoryShortcutStorageAdapter is used to hold the guild shortcuts in RAM.
+// Note that this will not survive a restart, so this is mainly useful for testing.
+type InMemoryShortcutStorageAdapter struct {
+	lock      *sync.RWMutex
+	shortcuts map[disgord.Snowflake]map[string]*Shortcut
+}
+
+// Init is used to initialise the in-memory shortcut storage.
+func (m *InMemoryShortcutStorageAdapter) Init() {
+	m.lock = &sync.RWMutex{}
+	m.shortcuts = map[disgord.Snowflake]map[string]*Shortcut{}
+}
+
+// Get is used to get a shortcut by its lowercase name. If it doesn't exist, this will be nil.
+func (m *InMemoryShortcutStorageAdapter) Get(GuildID disgord.Snowflake, Name string) (*Shortcut, error) {
+	m.lock.RLock()
+	shortcut := m.shortcuts[GuildID][Name]
+	m.lock.RUnlock()
+	return shortcut, nil
+}
+
+// Set is used to add or replace a shortcut.
+func (m *InMemoryShortcutStorageAdapter) Set(GuildID disgord.Snowflake, Value *Shortcut) error {
+	m.lock.Lock()
+	guild := m.shortcuts[GuildID]
+	if guild == nil {
+		guild = map[string]*Shortcut{}
+		m.shortcuts[GuildID] = guild
+	}
+	guild[Value.Name] = Value
+	m.lock.Unlock()
+	return nil
+}
+
+// Delete is used to delete a shortcut by its lowercase name.
+func (m *InMemoryShortcutStorageAdapter) Delete(GuildID disgord.Snowflake, Name string) error {
+	m.lock.Lock()
+	delete(m.shortcuts[GuildID], Name)
+	m.lock.Unlock()
+	return nil
+}
+
+// List is used to get all of the shortcuts of a guild, ordered by their name.
+func (m *InMemoryShortcutStorageAdapter) List(GuildID disgord.Snowflake) ([]*Shortcut, error) {
+	m.lock.RLock()
+	shortcuts := make([]*Shortcut, 0, len(m.shortcuts[GuildID]))
+	for _, v := range m.shortcuts[GuildID] {
+		shortcuts = append(shortcuts, v)
+	}
+	m.lock.RUnlock()
+	sort.Slice(shortcuts, func(i, j int) bool {
+		return shortcuts[i].Name < shortcuts[j].Name
+	})
+	return shortcuts, nil
+}
@@ -0,0 +1,37 @@
+package gommand
+
+import "sync"
+
+// InMemoryUsageStore is used to hold the usage of commands in RAM.
+// Note that this will not survive a restart, and every record is kept, so this is mainly useful for testing or small bots.
+type InMemoryUsageStore struct {
+	lock    *sync.RWMutex
+	records []*UsageRecord
+}
+
+// Init is used to initialise the in-memory usage store.
+func (m *InMemoryUsageStore) Init() {
+	m.lock = &sync.RWMutex{}
+	m.records = []*UsageRecord{}
+}
+
+// Record is used to store a usage record.
+func (m *InMemoryUsageStore) Record(Record *UsageRecord) error {
+	m.lock.Lock()
+	m.records = append(m.records, Record)
+	m.lock.Unlock()
+	return nil
+}
+
+// Query is used to get the records which match the query.
+func (m *InMemoryUsageStore) Query(Query *UsageQuery) ([]*UsageRecord, error) {
+	m.lock.RLock()
+	records := make([]*UsageRecord, 0)
+	for _, v := range m.records {
+		if Query.Matches(v) {
+			records = append(records, v)
+		}
+	}
+	m.lock.RUnlock()
+	return records, nil
+}
@@ -0,0 +1,93 @@
+package gommand
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Defines how a usage record is stored on each line. The IDs are stored as strings so that they are not mangled by anything which reads the file.
+type jsonUsageRecord struct {
+	Command string    `json:"command"`
+	GuildID string    `json:"guildId"`
+	UserID  string    `json:"userId"`
+	Time    time.Time `json:"time"`
+	Error   bool      `json:"error"`
+}
+
+// JSONLinesUsageStore is used to append the usage of commands to a file with one JSON record on each line.
+// Since the file is only appended to, it is safe to rotate or archive it while the bot is running.
+// Querying reads the whole file, so this is best suited to bots which aren't used a huge amount.
+type JSONLinesUsageStore struct {
+	// Path is the path to the file. This is created if it doesn't exist.
+	Path string
+
+	lock *sync.Mutex
+}
+
+// Init is used to initialise the usage store.
+func (j *JSONLinesUsageStore) Init() {
+	j.lock = &sync.Mutex{}
+}
+
+// Record is used to append a usage record to the file.
+func (j *JSONLinesUsageStore) Record(Record *UsageRecord) error {
+	b, err := json.Marshal(&jsonUsageRecord{
+		Command: Record.Command,
+		GuildID: Record.GuildID.String(),
+		UserID:  Record.UserID.String(),
+		Time:    Record.Time,
+		Error:   Record.Error,
+	})
+	if err != nil {
+		return err
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	f, err := os.OpenFile(j.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Query is used to get the records in the file which match the query. Lines which cannot be decoded (such as a partially written line) are skipped.
+func (j *JSONLinesUsageStore) Query(Query *UsageQuery) ([]*UsageRecord, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	records := make([]*UsageRecord, 0)
+	f, err := os.Open(j.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line jsonUsageRecord
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue
+		}
+		record := &UsageRecord{
+			Command: line.Command,
+			GuildID: disgord.ParseSnowflakeString(line.GuildID),
+			UserID:  disgord.ParseSnowflakeString(line.UserID),
+			Time:    line.Time,
+			Error:   line.Error,
+		}
+		if Query.Matches(record) {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
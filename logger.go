@@ -0,0 +1,115 @@
+package gommand
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// LogFields are the structured fields which are logged alongside a message.
+type LogFields = map[string]interface{}
+
+// Logger is used to define the interface which is used for structured logging within the router.
+type Logger interface {
+	// Debug is used to log debug information, such as a command finishing.
+	Debug(Message string, Fields LogFields)
+
+	// Info is used to log general information.
+	Info(Message string, Fields LogFields)
+
+	// Error is used to log errors which were not handled by any error handlers.
+	Error(Message string, Fields LogFields)
+}
+
+// Gets the fields which describe the invocation.
+func (c *Context) logFields() LogFields {
+	fields := LogFields{"shard": c.ShardID}
+	if c.Command != nil {
+		fields["command"] = c.CommandPath()
+	}
+	if c.Message != nil {
+		fields["guild"] = c.Message.GuildID
+		fields["channel"] = c.Message.ChannelID
+		fields["message_id"] = c.Message.ID
+		if c.Message.Author != nil {
+			fields["user"] = c.Message.Author.ID
+		}
+	}
+	return fields
+}
+
+// Logs that a command has finished running if a logger is set.
+func (r *Router) logCommandFinished(ctx *Context, cmd CommandInterface, duration time.Duration, err error) {
+	if r.Logger == nil {
+		return
+	}
+	fields := ctx.logFields()
+	fields["command"] = cmd.GetName()
+	fields["duration"] = duration
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	r.Logger.Debug("Command finished.", fields)
+}
+
+// Logs an error which was not handled by any error handlers.
+// If a logger is not set, the error is logged through the session logger.
+func (r *Router) logUnhandledError(ctx *Context, err error) {
+	p, isPanic := err.(*PanicError)
+	if r.Logger == nil {
+		if isPanic {
+			ctx.Session.Logger().Error(err, "\n"+string(p.Stack))
+		} else {
+			ctx.Session.Logger().Error(err)
+		}
+		return
+	}
+	fields := ctx.logFields()
+	fields["error"] = err.Error()
+	if isPanic {
+		fields["stack"] = string(p.Stack)
+		r.Logger.Error("Command panicked.", fields)
+		return
+	}
+	r.Logger.Error("Unhandled command error.", fields)
+}
+
+// Formats the message and fields as a single line, with the fields sorted by key.
+func formatLogLine(Message string, Fields LogFields) string {
+	keys := make([]string, 0, len(Fields))
+	for k := range Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys)+1)
+	parts[0] = Message
+	for i, k := range keys {
+		parts[i+1] = k + "=" + fmt.Sprint(Fields[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// The logger which wraps a disgord logger.
+type disgordLogger struct {
+	logger disgord.Logger
+}
+
+func (l *disgordLogger) Debug(Message string, Fields LogFields) {
+	l.logger.Debug(formatLogLine(Message, Fields))
+}
+
+func (l *disgordLogger) Info(Message string, Fields LogFields) {
+	l.logger.Info(formatLogLine(Message, Fields))
+}
+
+func (l *disgordLogger) Error(Message string, Fields LogFields) {
+	l.logger.Error(formatLogLine(Message, Fields))
+}
+
+// DisgordLogger is used to create a logger which writes to a disgord logger, with the fields formatted as key=value after the message.
+func DisgordLogger(Logger disgord.Logger) Logger {
+	return &disgordLogger{logger: Logger}
+}
@@ -0,0 +1,97 @@
+package gommand
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Records everything which was logged.
+type recordingLogger struct {
+	lock  sync.Mutex
+	lines []string
+	last  LogFields
+}
+
+func (l *recordingLogger) log(level, Message string, Fields LogFields) {
+	l.lock.Lock()
+	l.lines = append(l.lines, level+" "+Message)
+	l.last = Fields
+	l.lock.Unlock()
+}
+
+func (l *recordingLogger) Debug(Message string, Fields LogFields) { l.log("debug", Message, Fields) }
+func (l *recordingLogger) Info(Message string, Fields LogFields)  { l.log("info", Message, Fields) }
+func (l *recordingLogger) Error(Message string, Fields LogFields) { l.log("error", Message, Fields) }
+
+// TestLogger is used to test structured logging and panic errors.
+func TestLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Logger:      logger,
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return false
+	})
+	sentinel := errors.New("sentinel")
+	r.SetCommand(&Command{
+		Name: "ok",
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	r.SetCommand(&Command{
+		Name: "panic",
+		Function: func(ctx *Context) error {
+			panic(struct{ x int }{1})
+		},
+	})
+	r.SetCommand(&Command{
+		Name: "panicerr",
+		Function: func(ctx *Context) error {
+			panic(sentinel)
+		},
+	})
+
+	// Check a successful command is logged with the fields.
+	msg := mockMessage("%ok")
+	msg.ID = 5
+	msg.ChannelID = 2
+	msg.Author.ID = 4
+	r.CommandProcessor(nil, 3, msg, true)
+	if strings.Join(logger.lines, ", ") != "debug Command finished." {
+		t.Fatal("unexpected log lines:", logger.lines)
+	}
+	f := logger.last
+	if f["command"] != "ok" || f["guild"] != msg.GuildID || f["channel"] != msg.ChannelID || f["user"] != msg.Author.ID || f["shard"] != uint(3) || f["message_id"] != msg.ID {
+		t.Fatal("unexpected log fields:", f)
+	}
+	if _, ok := f["duration"].(time.Duration); !ok {
+		t.Fatal("expected the duration to be logged")
+	}
+
+	// Check that panics with values which are not strings or errors are reported with the stack.
+	logger.lines = nil
+	r.CommandProcessor(nil, 0, mockMessage("%panic"), true)
+	p, ok := lastErr.(*PanicError)
+	if !ok || p.Error() != "{1}" || len(p.Stack) == 0 {
+		t.Fatal("expected a panic error with a stack, got", lastErr)
+	}
+	if strings.Join(logger.lines, ", ") != "debug Command finished., error Command panicked." {
+		t.Fatal("unexpected log lines:", logger.lines)
+	}
+	if stack, _ := logger.last["stack"].(string); !strings.Contains(stack, "runtime/debug.Stack") {
+		t.Fatal("expected the stack to be logged")
+	}
+
+	// Check that error panics can be unwrapped.
+	r.CommandProcessor(nil, 0, mockMessage("%panicerr"), true)
+	if !errors.Is(lastErr, sentinel) {
+		t.Fatal("expected the panic error to wrap the error, got", lastErr)
+	}
+}
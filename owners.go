@@ -0,0 +1,118 @@
+package gommand
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// OwnerResolver is used to get the IDs of the bot owners.
+type OwnerResolver = func() ([]disgord.Snowflake, error)
+
+// The URL used to get the current application. This uses the same API version as the disgord REST client and is a variable so that it can be changed in tests.
+var applicationURL = "https://discord.com/api/v8/oauth2/applications/@me"
+
+// The HTTP client used to get the current application.
+var ownersHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// The longest time to wait before trying to resolve the owners again after a failure.
+const maxOwnersBackoff = 10 * time.Minute
+
+// ApplicationOwners is used to create a OwnerResolver which gets the owner of the Discord application using the bot token.
+// If the application belongs to a team, all of the team members are owners.
+func ApplicationOwners(Token string) OwnerResolver {
+	return func() ([]disgord.Snowflake, error) {
+		req, err := http.NewRequest("GET", applicationURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bot "+Token)
+		res, err := ownersHTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != 200 {
+			return nil, errors.New("failed to get the application: status " + strconv.Itoa(res.StatusCode))
+		}
+		var app struct {
+			Owner *disgord.User `json:"owner"`
+			Team  *struct {
+				Members []struct {
+					User *disgord.User `json:"user"`
+				} `json:"members"`
+			} `json:"team"`
+		}
+		if err = json.NewDecoder(res.Body).Decode(&app); err != nil {
+			return nil, err
+		}
+		ids := make([]disgord.Snowflake, 0, 1)
+		if app.Team != nil {
+			for _, v := range app.Team.Members {
+				if v.User != nil {
+					ids = append(ids, v.User.ID)
+				}
+			}
+		} else if app.Owner != nil {
+			ids = append(ids, app.Owner.ID)
+		}
+		return ids, nil
+	}
+}
+
+// Used to resolve the owners if this is required. The owners lock must be held, and is released while the resolver runs.
+// Only one call resolves the owners at a time, and the owners which are already known are used by any other calls until it is done.
+func (r *Router) resolveOwners() {
+	if r.ownersResolved || r.ownersResolving || r.ownerResolver == nil || r.clock().Now().Before(r.ownersRetry) {
+		return
+	}
+	r.ownersResolving = true
+	r.ownersLock.Unlock()
+	ids, err := r.ownerResolver()
+	r.ownersLock.Lock()
+	r.ownersResolving = false
+	if err != nil {
+		// Wait longer after each failure before trying again.
+		backoff := maxOwnersBackoff
+		if r.ownersFailures < 10 {
+			backoff = time.Second << uint(r.ownersFailures)
+			if backoff > maxOwnersBackoff {
+				backoff = maxOwnersBackoff
+			}
+		}
+		r.ownersFailures++
+		r.ownersRetry = r.clock().Now().Add(backoff)
+		if r.Logger != nil {
+			r.Logger.Error("Failed to resolve the bot owners.", LogFields{"error": err.Error(), "retry_in": backoff.String()})
+		}
+		return
+	}
+	r.ownerIDs = append(r.ownerIDs, ids...)
+	r.ownersResolved = true
+}
+
+// IsOwner is used to check if the user is one of the bot owners.
+// If a OwnerResolver is set, the owners will be resolved the first time this is called. If this fails, it is tried again after a backoff.
+func (r *Router) IsOwner(UserID disgord.Snowflake) bool {
+	r.ownersLock.Lock()
+	defer r.ownersLock.Unlock()
+	r.resolveOwners()
+	for _, v := range r.ownerIDs {
+		if v == UserID {
+			return true
+		}
+	}
+	return false
+}
+
+// BotOwnerOnly is a permission validator which only allows the bot owners to run the command.
+func BotOwnerOnly(ctx *Context) (string, bool) {
+	if ctx.Router.IsOwner(ctx.Message.Author.ID) {
+		return "", true
+	}
+	return ctx.Translate(MessageBotOwnerOnly), false
+}
@@ -0,0 +1,112 @@
+package gommand
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// TestApplicationOwners is used to test getting the owners from the Discord application.
+func TestApplicationOwners(t *testing.T) {
+	body := `{"owner": {"id": "1"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bot token" {
+			w.WriteHeader(401)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	old := applicationURL
+	applicationURL = srv.URL
+	defer func() {
+		applicationURL = old
+	}()
+
+	ids, err := ApplicationOwners("token")()
+	if err != nil || len(ids) != 1 || ids[0] != 1 {
+		t.Fatal("unexpected owners:", ids, err)
+	}
+	body = `{"owner": {"id": "1"}, "team": {"members": [{"user": {"id": "2"}}, {"user": {"id": "3"}}]}}`
+	ids, err = ApplicationOwners("token")()
+	if err != nil || len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Fatal("unexpected team owners:", ids, err)
+	}
+	if _, err = ApplicationOwners("wrong")(); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+// TestBotOwnerOnly is used to test the owner permission validator and hidden commands.
+func TestBotOwnerOnly(t *testing.T) {
+	calls := 0
+	clock := &testClock{now: time.Unix(0, 0)}
+	var r *Router
+	r = NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Clock:       clock,
+		OwnerIDs:    []disgord.Snowflake{1},
+		OwnerResolver: func() ([]disgord.Snowflake, error) {
+			calls++
+			// The owners lock should not be held while resolving.
+			if !r.IsOwner(1) {
+				t.Error("the static owner should be an owner while resolving")
+			}
+			if calls == 1 {
+				return nil, errors.New("unavailable")
+			}
+			return []disgord.Snowflake{2}, nil
+		},
+	})
+	if !r.IsOwner(1) || calls != 1 {
+		t.Fatal("the static owner should be an owner")
+	}
+	if r.IsOwner(2) || calls != 1 {
+		t.Fatal("the owners should not be resolved again until the backoff has passed")
+	}
+	clock.now = clock.now.Add(time.Second)
+	if !r.IsOwner(2) || calls != 2 || r.IsOwner(3) || calls != 2 {
+		t.Fatal("the owners should be resolved until it succeeds")
+	}
+
+	// Check the validator and that hidden commands are only shown to owners.
+	ran := false
+	r.SetCommand(&Command{
+		Name:                 "secret",
+		Hidden:               true,
+		PermissionValidators: []PermissionValidator{BotOwnerOnly},
+		Function: func(ctx *Context) error {
+			ran = true
+			return nil
+		},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	h := newHelpHandler(&HelpConfig{})
+	for _, v := range []struct {
+		user  disgord.Snowflake
+		owner bool
+	}{{1, true}, {3, false}} {
+		msg := mockMessage("%secret")
+		msg.Author.ID = v.user
+		ran, lastErr = false, nil
+		r.CommandProcessor(nil, 0, msg, true)
+		if ran != v.owner {
+			t.Fatal("unexpected result for user", v.user, lastErr)
+		}
+		embed := h.createCommandEmbed(&Context{Router: r, Message: msg}, []string{"secret"})
+		if (embed.Title == "Command not found:") == v.owner {
+			t.Fatal("the hidden command should only be shown to owners")
+		}
+	}
+	if e, ok := lastErr.(*IncorrectPermissions); !ok || e.Error() != "This command can only be used by the bot owners." {
+		t.Fatal("unexpected error:", lastErr)
+	}
+}
@@ -0,0 +1,276 @@
+package gommand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// PaginatorDataSource is the interface which is used to lazily load pages for the paginator.
+type PaginatorDataSource interface {
+	// PageCount should return the number of pages, or -1 if this is unknown.
+	PageCount() int
+
+	// FetchPage should fetch the items for the page specified (starting at 1).
+	// If the page count is unknown, returning no items means that the page does not exist.
+	FetchPage(Page int) ([]interface{}, error)
+}
+
+// SlicePaginatorDataSource is a paginator data source which splits a slice of items into pages.
+type SlicePaginatorDataSource struct {
+	// Items are the items which will be paginated.
+	Items []interface{}
+
+	// PerPage is the number of items on each page. 0 will default to 10.
+	PerPage int
+}
+
+// Gets the number of items per page.
+func (s *SlicePaginatorDataSource) perPage() int {
+	if s.PerPage <= 0 {
+		return 10
+	}
+	return s.PerPage
+}
+
+// PageCount is used to get the number of pages.
+func (s *SlicePaginatorDataSource) PageCount() int {
+	perPage := s.perPage()
+	return (len(s.Items) + perPage - 1) / perPage
+}
+
+// FetchPage is used to get the items for a page.
+func (s *SlicePaginatorDataSource) FetchPage(Page int) ([]interface{}, error) {
+	perPage := s.perPage()
+	start := (Page - 1) * perPage
+	if Page < 1 || start >= len(s.Items) {
+		return []interface{}{}, nil
+	}
+	end := start + perPage
+	if end > len(s.Items) {
+		end = len(s.Items)
+	}
+	return s.Items[start:end], nil
+}
+
+// Paginator is used to paginate a lazily loaded data source, only fetching pages when they are needed.
+type Paginator struct {
+	// Source is the data source which pages are fetched from.
+	Source PaginatorDataSource
+
+	// EmbedFormatter is used to render the items of a page into an embed. The page footer is set automatically.
+	// If this is nil (or it returns nil), TextFormatter will be used for the embed description.
+	EmbedFormatter func(Page int, Items []interface{}) *disgord.Embed
+
+	// TextFormatter is used to render the items of a page into text. If this is nil, each item will be put on a new line.
+	TextFormatter func(Page int, Items []interface{}) string
+
+	// NoButtonTextContent is the text content which is sent with the page when the bot cannot use embed menus.
+	NoButtonTextContent string
+
+	// Lifetime is used to control the lifetime of the paginator. This can be nil.
+	Lifetime *EmbedLifetimeOptions
+
+	// JumpTimeout is how long the paginator waits for a page number after the jump button is pressed. 0 will default to 30 seconds.
+	JumpTimeout time.Duration
+
+	cache     map[int][]interface{}
+	cacheLock sync.Mutex
+}
+
+// Gets a page from the cache, or fetches it from the data source if it isn't cached.
+// The cache is not locked while the page is fetched, meaning a slow data source does not hold up other pages.
+func (p *Paginator) getPage(Page int) ([]interface{}, error) {
+	p.cacheLock.Lock()
+	items, ok := p.cache[Page]
+	p.cacheLock.Unlock()
+	if ok {
+		return items, nil
+	}
+	items, err := p.Source.FetchPage(Page)
+	if err != nil {
+		return nil, err
+	}
+	p.cacheLock.Lock()
+	if p.cache == nil {
+		p.cache = map[int][]interface{}{}
+	}
+	p.cache[Page] = items
+	p.cacheLock.Unlock()
+	return items, nil
+}
+
+// Checks if a page exists.
+func (p *Paginator) pageExists(Page int) bool {
+	if Page < 1 {
+		return false
+	}
+	count := p.Source.PageCount()
+	if count >= 0 {
+		return count >= Page
+	}
+	items, err := p.getPage(Page)
+	return err == nil && len(items) != 0
+}
+
+// Renders the page into an embed.
+func (p *Paginator) renderPage(ctx *Context, Page int) (*disgord.Embed, error) {
+	items, err := p.getPage(Page)
+	if err != nil {
+		return nil, err
+	}
+	var em *disgord.Embed
+	if p.EmbedFormatter != nil {
+		if formatted := p.EmbedFormatter(Page, items); formatted != nil {
+			em = disgord.DeepCopy(formatted).(*disgord.Embed)
+		}
+	}
+	if em == nil {
+		em = &disgord.Embed{Description: p.renderText(Page, items)}
+	}
+	footer := ctx.Translate(MessagePageFooterUnknownCount, "page", strconv.Itoa(Page))
+	if count := p.Source.PageCount(); count >= 0 {
+		footer = ctx.Translate(MessagePageFooter, "page", strconv.Itoa(Page), "pages", strconv.Itoa(count))
+	}
+	em.Footer = &disgord.EmbedFooter{Text: footer}
+	return em, nil
+}
+
+// Renders the items into text.
+func (p *Paginator) renderText(Page int, Items []interface{}) string {
+	if p.TextFormatter != nil {
+		return p.TextFormatter(Page, Items)
+	}
+	lines := make([]string, len(Items))
+	for i, v := range Items {
+		lines[i] = fmt.Sprint(v)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Waits for the user to type the page number they want to jump to.
+func (p *Paginator) waitForPageNumber(ctx *Context, ChannelID disgord.Snowflake, client disgord.Session) int {
+	timeout := p.JumpTimeout
+	if timeout == 0 {
+		timeout = time.Second * 30
+	}
+	prompt, err := client.SendMsg(ChannelID, ctx.Translate(MessagePageJumpQuestion))
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = client.Channel(ChannelID).Message(prompt.ID).Delete() }()
+	waitCtx, cancel := withClockTimeout(ctx.Router.clock(), context.Background(), timeout)
+	defer cancel()
+	resp := ctx.WaitForMessage(waitCtx, func(_ disgord.Session, msg *disgord.Message) bool {
+		return msg.ChannelID == ChannelID && msg.Author.ID == ctx.Message.Author.ID
+	})
+	if resp == nil {
+		return 0
+	}
+	_ = client.Channel(ChannelID).Message(resp.ID).Delete()
+	page, err := UIntTransformer(ctx, strings.TrimSpace(resp.Content))
+	if err != nil {
+		return 0
+	}
+	return int(page.(uint64))
+}
+
+// Creates the embed menu for the page.
+func (p *Paginator) menuForPage(ctx *Context, Page int) (*EmbedMenu, error) {
+	em, err := p.renderPage(ctx, Page)
+	if err != nil {
+		return nil, err
+	}
+	menu := NewEmbedMenu(em, ctx)
+	display := func(NewPage int, ChannelID, MessageID disgord.Snowflake, client disgord.Session) {
+		if !p.pageExists(NewPage) {
+			return
+		}
+		m, err := p.menuForPage(ctx, NewPage)
+		if err != nil {
+			return
+		}
+		_ = m.Display(ChannelID, MessageID, client)
+	}
+	if Page > 1 {
+		menu.Reactions.Add(MenuReaction{
+			Button: &MenuButton{
+				Emoji:       "◀️",
+				Name:        ctx.Translate(MessagePageBack),
+				Description: ctx.Translate(MessagePageBackDescription),
+			},
+			Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
+				display(Page-1, ChannelID, MessageID, client)
+			},
+		})
+	}
+	if count := p.Source.PageCount(); count < 0 || Page < count {
+		menu.Reactions.Add(MenuReaction{
+			Button: &MenuButton{
+				Emoji:       "▶️",
+				Name:        ctx.Translate(MessagePageForward),
+				Description: ctx.Translate(MessagePageForwardDescription),
+			},
+			Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
+				display(Page+1, ChannelID, MessageID, client)
+			},
+		})
+	}
+	menu.Reactions.Add(MenuReaction{
+		Button: &MenuButton{
+			Emoji:       "🔢",
+			Name:        ctx.Translate(MessagePageJump),
+			Description: ctx.Translate(MessagePageJumpDescription),
+		},
+		Function: func(ChannelID, MessageID disgord.Snowflake, _ *EmbedMenu, client disgord.Session) {
+			if NewPage := p.waitForPageNumber(ctx, ChannelID, client); NewPage != 0 {
+				display(NewPage, ChannelID, MessageID, client)
+			}
+		},
+	})
+	return menu, nil
+}
+
+// Display is used to display the paginator at the page specified (starting at 1).
+func (p *Paginator) Display(ctx *Context, InitialPage uint) error {
+	// Check the permissions which the bot has permission to use embed menus in this channel.
+	UseEmbedMenus, err := canUseEmbedMenus(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the initial page exists.
+	page := int(InitialPage)
+	if !p.pageExists(page) {
+		page = 1
+		if !p.pageExists(page) {
+			return errors.New("pages length is 0")
+		}
+	}
+
+	// If we cannot use embed menus, just send the page.
+	if !UseEmbedMenus {
+		em, err := p.renderPage(ctx, page)
+		if err != nil {
+			return err
+		}
+		_, err = ctx.Reply(p.NoButtonTextContent, em)
+		return err
+	}
+
+	// Display the embed menu.
+	menu, err := p.menuForPage(ctx, page)
+	if err != nil {
+		return err
+	}
+	if p.Lifetime == nil {
+		return ctx.DisplayEmbedMenu(menu)
+	}
+	return ctx.DisplayEmbedMenuWithLifetime(menu, p.Lifetime)
+}
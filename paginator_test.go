@@ -0,0 +1,125 @@
+package gommand
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Used to count how many times pages are fetched.
+type countingDataSource struct {
+	fetches int
+}
+
+func (c *countingDataSource) PageCount() int {
+	return -1
+}
+
+func (c *countingDataSource) FetchPage(Page int) ([]interface{}, error) {
+	c.fetches++
+	if Page > 3 {
+		return []interface{}{}, nil
+	}
+	return []interface{}{Page * 10, Page*10 + 1}, nil
+}
+
+// Used to block fetching the second page until it is released.
+type blockingDataSource struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingDataSource) PageCount() int {
+	return 2
+}
+
+func (b *blockingDataSource) FetchPage(Page int) ([]interface{}, error) {
+	if Page == 2 {
+		close(b.started)
+		<-b.release
+	}
+	return []interface{}{Page}, nil
+}
+
+// TestPaginatorSlowSource is used to test that a slow data source does not stop other pages from being fetched.
+func TestPaginatorSlowSource(t *testing.T) {
+	source := &blockingDataSource{started: make(chan struct{}), release: make(chan struct{})}
+	p := &Paginator{Source: source}
+	if _, err := p.getPage(1); err != nil {
+		t.Fatal(err)
+	}
+	fetched := make(chan struct{})
+	go func() {
+		_, _ = p.getPage(2)
+		close(fetched)
+	}()
+	<-source.started
+	got := make(chan []interface{})
+	go func() {
+		items, _ := p.getPage(1)
+		got <- items
+	}()
+	select {
+	case items := <-got:
+		if len(items) != 1 || items[0] != 1 {
+			t.Fatal("unexpected page:", items)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the cached page was blocked by the page being fetched")
+	}
+	close(source.release)
+	<-fetched
+	if items, _ := p.getPage(2); len(items) != 1 || items[0] != 2 {
+		t.Fatal("the fetched page was not cached:", items)
+	}
+}
+
+// TestPaginator is used to test that the paginator fetches and renders pages properly.
+func TestPaginator(t *testing.T) {
+	// Test the slice data source.
+	s := &SlicePaginatorDataSource{Items: []interface{}{1, 2, 3, 4, 5}, PerPage: 2}
+	if s.PageCount() != 3 {
+		t.Fatal("invalid page count:", s.PageCount())
+	}
+	items, _ := s.FetchPage(3)
+	if len(items) != 1 || items[0] != 5 {
+		t.Fatal("invalid last page:", items)
+	}
+
+	// Test that pages are cached.
+	source := &countingDataSource{}
+	p := &Paginator{Source: source}
+	_, _ = p.getPage(1)
+	_, _ = p.getPage(1)
+	if source.fetches != 1 {
+		t.Fatal("page was fetched more than once")
+	}
+
+	// Test page existence with an unknown page count.
+	if !p.pageExists(3) || p.pageExists(4) || p.pageExists(0) {
+		t.Fatal("page existence is incorrect")
+	}
+
+	// Test rendering.
+	em, err := p.renderPage(nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if em.Description != "20\n21" || em.Footer.Text != "Page 2" {
+		t.Fatal("invalid render:", em.Description, em.Footer.Text)
+	}
+	em, _ = (&Paginator{Source: s}).renderPage(nil, 1)
+	if em.Footer.Text != "Page 1/3" {
+		t.Fatal("invalid footer:", em.Footer.Text)
+	}
+
+	// A formatter returning nil should fall back to the text formatter.
+	p.EmbedFormatter = func(int, []interface{}) *disgord.Embed {
+		return nil
+	}
+	em, err = p.renderPage(nil, 1)
+	if err != nil || em.Description != "10\n11" {
+		t.Fatal("nil embed was not rendered as text:", err)
+	}
+}
@@ -0,0 +1,182 @@
+package gommand
+
+import (
+	"bytes"
+
+	"github.com/andersfylling/disgord"
+)
+
+// PersistentMenu is the information which is persisted about a displayed menu so that it can be rebuilt after a restart.
+type PersistentMenu struct {
+	// MenuID is the ID the menu builder was registered with on the router.
+	MenuID string `json:"menuId"`
+
+	// State is the serialized state of the menu which is passed to the menu builder.
+	State []byte `json:"state"`
+
+	// Author is the ID of the user who is allowed to use the menu.
+	Author string `json:"author"`
+
+	// BotID is the ID of the bot user who created the menu.
+	BotID disgord.Snowflake `json:"botId"`
+
+	// ChannelID is the ID of the channel the menu is in.
+	ChannelID disgord.Snowflake `json:"channelId"`
+
+	// Path is the position of the child menu which was displayed, as the index of each child menu from the menu the builder creates.
+	// This is empty if the menu the builder creates was displayed.
+	Path []int `json:"path,omitempty"`
+}
+
+// MenuBuilder is used to rebuild a persistent menu from its persisted information.
+// The menu returned only needs the embed and reactions set, the remainder is patched in from the persisted information.
+type MenuBuilder = func(s disgord.Session, info *PersistentMenu) (*EmbedMenu, error)
+
+// MenuStorageAdapter is the interface which is used for menu storage adapters.
+// Persistent menus are stored through this so that reactions on them continue to work after a restart.
+type MenuStorageAdapter interface {
+	// Called when the router is created.
+	Init()
+
+	// Related to menu persistence.
+	Get(MessageID disgord.Snowflake) *PersistentMenu
+	Set(MessageID disgord.Snowflake, Menu *PersistentMenu)
+	Delete(MessageID disgord.Snowflake)
+}
+
+// RegisterMenu is used to register a menu builder with a stable ID.
+// Any menu which is displayed with its MenuID set to this ID will be rebuilt with the builder the first time it is reacted to after a restart.
+// If a child menu which inherited the ID was displayed, the child menu at the same position of the rebuilt menu is used.
+func (r *Router) RegisterMenu(MenuID string, Builder MenuBuilder) {
+	r.menuBuildersLock.Lock()
+	r.menuBuilders[MenuID] = Builder
+	r.menuBuildersLock.Unlock()
+}
+
+// Stores the menu in the storage adapter if it is persistent, or removes it if it isn't.
+func (r *Router) persistMenu(MessageID disgord.Snowflake, ChannelID disgord.Snowflake, e *EmbedMenu) {
+	if r.MenuStorageAdapter == nil {
+		return
+	}
+	if e.MenuID == "" || len(e.Reactions.ReactionSlice) == 0 {
+		r.MenuStorageAdapter.Delete(MessageID)
+		return
+	}
+	author := ""
+	if e.MenuInfo != nil {
+		author = e.MenuInfo.Author
+	}
+	r.MenuStorageAdapter.Set(MessageID, &PersistentMenu{
+		MenuID:    e.MenuID,
+		State:     e.MenuState,
+		Author:    author,
+		BotID:     e.myID,
+		ChannelID: ChannelID,
+		Path:      menuPath(e),
+	})
+}
+
+// Gets the path to the menu from the menu which the builder creates. This is the index of each child menu which inherited its ID and state from its parent.
+func menuPath(e *EmbedMenu) []int {
+	path := []int{}
+	for e.parent != nil && e.MenuID == e.parent.MenuID && bytes.Equal(e.MenuState, e.parent.MenuState) {
+		index := -1
+		for i, v := range e.parent.children {
+			if v == e {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			// The parent was added with AddParentMenu rather than creating this as a child.
+			break
+		}
+		path = append([]int{index}, path...)
+		e = e.parent
+	}
+	return path
+}
+
+// Patches the persisted information into the menu and its child menus. Child menus without a menu ID inherit it from their parent.
+func (r *Router) patchPersistedMenu(menu *EmbedMenu, info *PersistentMenu, MenuID string, State []byte) {
+	menu.router = r
+	menu.myID = info.BotID
+	if menu.MenuID == "" {
+		menu.MenuID = MenuID
+	}
+	if menu.MenuState == nil {
+		menu.MenuState = State
+	}
+	if menu.MenuInfo == nil {
+		menu.MenuInfo = &MenuInfo{Info: []string{}}
+	}
+	menu.MenuInfo.Author = info.Author
+	if menu.Reactions == nil {
+		menu.Reactions = &MenuReactions{ReactionSlice: []MenuReaction{}}
+	}
+	for _, v := range menu.children {
+		r.patchPersistedMenu(v, info, menu.MenuID, menu.MenuState)
+	}
+}
+
+// Rebuilds a menu from the storage adapter if it exists there. Returns nil if it cannot be rebuilt.
+// This is done lazily when the menu is reacted to rather than when the router starts.
+func (r *Router) rehydrateMenu(s disgord.Session, MessageID disgord.Snowflake) *EmbedMenu {
+	if r.MenuStorageAdapter == nil {
+		return nil
+	}
+	info := r.MenuStorageAdapter.Get(MessageID)
+	if info == nil {
+		return nil
+	}
+	r.menuBuildersLock.RLock()
+	builder := r.menuBuilders[info.MenuID]
+	r.menuBuildersLock.RUnlock()
+	if builder == nil {
+		return nil
+	}
+	menu, err := builder(s, info)
+	if err != nil || menu == nil {
+		return nil
+	}
+
+	// Patch in the persisted information and get the child menu which was displayed.
+	menu.MenuID = info.MenuID
+	r.patchPersistedMenu(menu, info, info.MenuID, info.State)
+	for _, i := range info.Path {
+		if i < 0 || i >= len(menu.children) {
+			return nil
+		}
+		menu = menu.children[i]
+	}
+
+	// Put the menu back into the cache.
+	menuCacheLock.Lock()
+	menuCache[MessageID] = menu
+	menuCacheLock.Unlock()
+	return menu
+}
+
+// Handles menu reactions, rehydrating the menu from the storage adapter if it is not cached.
+func (r *Router) menuReactionAdd(s disgord.Session, evt *disgord.MessageReactionAdd) {
+	go func() {
+		menuCacheLock.RLock()
+		menu := menuCache[evt.MessageID]
+		menuCacheLock.RUnlock()
+		if menu == nil {
+			menu = r.rehydrateMenu(s, evt.MessageID)
+			if menu == nil {
+				return
+			}
+		}
+		handleMenuReaction(s, evt, menu)
+	}()
+}
+
+// Handles menu messages being deleted, removing them from the storage adapter.
+func (r *Router) menuMessageDelete(s disgord.Session, evt *disgord.MessageDelete) {
+	handleEmbedMenuMessageDelete(s, evt)
+	if r.MenuStorageAdapter != nil {
+		go r.MenuStorageAdapter.Delete(evt.MessageID)
+	}
+}
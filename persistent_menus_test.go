@@ -0,0 +1,152 @@
+package gommand
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// TestPersistentMenus is used to test that persisted menus are rebuilt from the storage adapter.
+func TestPersistentMenus(t *testing.T) {
+	r := NewRouter(&RouterConfig{
+		MenuStorageAdapter: &InMemoryMenuStorageAdapter{},
+	})
+	build := func(menu *EmbedMenu) *EmbedMenu {
+		menu.Reactions = &MenuReactions{
+			ReactionSlice: []MenuReaction{{Button: &MenuButton{Emoji: "▶️"}}},
+		}
+		menu.NewChildMenu(&ChildMenuOptions{Embed: &disgord.Embed{Title: "first child"}, Button: &MenuButton{Emoji: "1️⃣"}})
+		child := menu.NewChildMenu(&ChildMenuOptions{Embed: &disgord.Embed{Title: "second child"}, Button: &MenuButton{Emoji: "2️⃣"}})
+		grandchild := child.NewChildMenu(&ChildMenuOptions{Embed: &disgord.Embed{Title: "grandchild"}, Button: &MenuButton{Emoji: "3️⃣"}})
+		grandchild.Reactions.Add(MenuReaction{Button: &MenuButton{Emoji: "⬆"}})
+		return menu
+	}
+	r.RegisterMenu("test", func(_ disgord.Session, info *PersistentMenu) (*EmbedMenu, error) {
+		return build(&EmbedMenu{Embed: &disgord.Embed{Title: string(info.State)}}), nil
+	})
+
+	// Persist the menu as if it had been displayed before a restart.
+	menu := &EmbedMenu{
+		MenuID:    "test",
+		MenuState: []byte("page 2"),
+		MenuInfo:  &MenuInfo{Author: "1"},
+		Reactions: &MenuReactions{
+			ReactionSlice: []MenuReaction{{Button: &MenuButton{Emoji: "▶️"}}},
+		},
+		myID: 2,
+	}
+	r.persistMenu(3, 4, menu)
+
+	// Rebuild the menu.
+	rebuilt := r.rehydrateMenu(nil, 3)
+	if rebuilt == nil {
+		t.Fatal("menu was not rebuilt")
+	}
+	if rebuilt.Embed.Title != "page 2" || rebuilt.MenuInfo.Author != "1" || rebuilt.myID != 2 || rebuilt.router != r {
+		t.Fatal("menu was not rebuilt with the persisted information")
+	}
+	menuCacheLock.Lock()
+	delete(menuCache, 3)
+	menuCacheLock.Unlock()
+
+	// Child menus which inherit the ID should be rebuilt at the same position.
+	root := build(&EmbedMenu{
+		Embed:     &disgord.Embed{Title: "page 3"},
+		MenuID:    "test",
+		MenuState: []byte("page 3"),
+		MenuInfo:  &MenuInfo{Author: "1"},
+		myID:      2,
+	})
+	grandchild := root.children[1].children[0]
+	r.persistMenu(6, 4, grandchild)
+	if p := r.MenuStorageAdapter.Get(6).Path; len(p) != 2 || p[0] != 1 || p[1] != 0 {
+		t.Fatal("unexpected path:", p)
+	}
+	rebuilt = r.rehydrateMenu(nil, 6)
+	if rebuilt == nil || rebuilt.Embed.Title != "grandchild" || rebuilt.parent.parent.Embed.Title != "page 3" {
+		t.Fatal("the child menu was not rebuilt")
+	}
+	if rebuilt.MenuID != "test" || string(rebuilt.MenuState) != "page 3" || rebuilt.MenuInfo.Author != "1" || rebuilt.router != r {
+		t.Fatal("the child menu was not rebuilt with the persisted information")
+	}
+
+	// Child menus with their own state are persisted as they are.
+	own := root.children[1]
+	own.MenuState = []byte("own")
+	r.persistMenu(7, 4, own)
+	if info := r.MenuStorageAdapter.Get(7); len(info.Path) != 0 || string(info.State) != "own" {
+		t.Fatal("the child menu should be persisted with its own state")
+	}
+	menuCacheLock.Lock()
+	delete(menuCache, 6)
+	menuCacheLock.Unlock()
+
+	// Menus with unknown ID's should not be rebuilt.
+	r.persistMenu(5, 4, &EmbedMenu{MenuID: "unknown", Reactions: menu.Reactions})
+	if r.rehydrateMenu(nil, 5) != nil {
+		t.Fatal("menu with unknown ID was rebuilt")
+	}
+
+	// Menus without a menu ID should be removed from storage.
+	r.persistMenu(3, 4, &EmbedMenu{Reactions: menu.Reactions})
+	if r.MenuStorageAdapter.Get(3) != nil {
+		t.Fatal("non-persistent menu was not removed")
+	}
+}
+
+// Used to make deleting messages fail.
+type failingDeleteSession struct {
+	disgord.Session
+}
+
+func (failingDeleteSession) Channel(disgord.Snowflake) disgord.ChannelQueryBuilder {
+	return failingDeleteChannel{}
+}
+
+type failingDeleteChannel struct {
+	disgord.ChannelQueryBuilder
+}
+
+func (failingDeleteChannel) Message(disgord.Snowflake) disgord.MessageQueryBuilder {
+	return failingDeleteMessage{}
+}
+
+type failingDeleteMessage struct {
+	disgord.MessageQueryBuilder
+}
+
+func (failingDeleteMessage) Delete(...disgord.Flag) error {
+	return errors.New("missing permissions")
+}
+
+// TestPersistentMenuExpiry is used to test that persisted menus are removed from the storage adapter when they expire, even if the message can't be deleted.
+func TestPersistentMenuExpiry(t *testing.T) {
+	r := NewRouter(&RouterConfig{
+		MenuStorageAdapter: &InMemoryMenuStorageAdapter{},
+	})
+	menu := &EmbedMenu{
+		MenuID:   "test",
+		MenuInfo: &MenuInfo{Author: "1"},
+		Reactions: &MenuReactions{
+			ReactionSlice: []MenuReaction{{Button: &MenuButton{Emoji: "▶️"}}},
+		},
+	}
+	r.persistMenu(6, 4, menu)
+	menuCacheLock.Lock()
+	menuCache[6] = menu
+	menuCacheLock.Unlock()
+
+	lifetime := &EmbedLifetimeOptions{MaximumLifetime: time.Minute, router: r}
+	lifetime.expire(4, 6, failingDeleteSession{})
+	if r.MenuStorageAdapter.Get(6) != nil {
+		t.Fatal("expired menu was not removed from the storage adapter")
+	}
+	menuCacheLock.RLock()
+	_, ok := menuCache[6]
+	menuCacheLock.RUnlock()
+	if ok {
+		t.Fatal("expired menu was not removed from the cache")
+	}
+}
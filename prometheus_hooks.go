@@ -0,0 +1,204 @@
+package gommand
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The default latency buckets in seconds. These are the same as the Prometheus client defaults.
+var defaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Defines a latency histogram for a command.
+type latencyHistogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// PrometheusHooks implements the Hooks interface and is used to record metrics about commands.
+// This implements http.Handler, which serves the metrics in the Prometheus text format.
+// Please call NewPrometheusHooks to initialise this rather than creating a new struct.
+type PrometheusHooks struct {
+	lock             sync.Mutex
+	buckets          []float64
+	prefixMatches    uint64
+	resolved         map[string]uint64
+	permissionDenied map[string]uint64
+	cooldownHits     map[string]uint64
+	finished         map[[2]string]uint64
+	errors           map[[2]string]uint64
+	latencies        map[string]*latencyHistogram
+}
+
+// NewPrometheusHooks is used to create new Prometheus hooks. If no latency buckets (in seconds) are given, the Prometheus client defaults are used.
+func NewPrometheusHooks(Buckets ...float64) *PrometheusHooks {
+	if len(Buckets) == 0 {
+		Buckets = defaultLatencyBuckets
+	}
+	Buckets = append([]float64{}, Buckets...)
+	sort.Float64s(Buckets)
+	return &PrometheusHooks{
+		buckets:          Buckets,
+		resolved:         map[string]uint64{},
+		permissionDenied: map[string]uint64{},
+		cooldownHits:     map[string]uint64{},
+		finished:         map[[2]string]uint64{},
+		errors:           map[[2]string]uint64{},
+		latencies:        map[string]*latencyHistogram{},
+	}
+}
+
+// Gets the class of an error. This is the name of its type without the package or pointer.
+func errorClass(err error) string {
+	name := fmt.Sprintf("%T", err)
+	if i := strings.LastIndex(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+	return strings.TrimLeft(name, "*")
+}
+
+// PrefixMatched is used to count the messages which used the prefix.
+func (p *PrometheusHooks) PrefixMatched(*Context) {
+	p.lock.Lock()
+	p.prefixMatches++
+	p.lock.Unlock()
+}
+
+// CommandResolved is used to count the commands which were found.
+func (p *PrometheusHooks) CommandResolved(_ *Context, cmd CommandInterface) {
+	p.lock.Lock()
+	p.resolved[cmd.GetName()]++
+	p.lock.Unlock()
+}
+
+// PermissionDenied is used to count the commands which the user did not have permission to run.
+func (p *PrometheusHooks) PermissionDenied(_ *Context, cmd CommandInterface, _ *IncorrectPermissions) {
+	p.lock.Lock()
+	p.permissionDenied[cmd.GetName()]++
+	p.lock.Unlock()
+}
+
+// CooldownHit is used to count the commands which were on cooldown.
+func (p *PrometheusHooks) CooldownHit(_ *Context, cmd CommandInterface, _ *CommandOnCooldown) {
+	p.lock.Lock()
+	p.cooldownHits[cmd.GetName()]++
+	p.lock.Unlock()
+}
+
+// ArgsParsed does nothing.
+func (p *PrometheusHooks) ArgsParsed(*Context, CommandInterface) {}
+
+// CommandStarted does nothing.
+func (p *PrometheusHooks) CommandStarted(*Context, CommandInterface) {}
+
+// CommandFinished is used to record the result and latency of the command.
+func (p *PrometheusHooks) CommandFinished(_ *Context, cmd CommandInterface, duration time.Duration, err error) {
+	name := cmd.GetName()
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.finished[[2]string{name, result}]++
+	if err != nil {
+		p.errors[[2]string{name, errorClass(err)}]++
+	}
+	h, ok := p.latencies[name]
+	if !ok {
+		h = &latencyHistogram{counts: make([]uint64, len(p.buckets))}
+		p.latencies[name] = h
+	}
+	seconds := duration.Seconds()
+	for i, v := range p.buckets {
+		if seconds <= v {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// Escapes a Prometheus label value.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// Formats a float in the way Prometheus expects.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// Writes a counter keyed by command.
+func writeCommandCounter(b *strings.Builder, name, help string, counts map[string]uint64) {
+	b.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " counter\n")
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(name + `{command="` + escapeLabel(k) + `"} ` + strconv.FormatUint(counts[k], 10) + "\n")
+	}
+}
+
+// Writes a counter keyed by command and another label.
+func writeLabelledCounter(b *strings.Builder, name, help, label string, counts map[[2]string]uint64) {
+	b.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " counter\n")
+	keys := make([][2]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] == keys[j][0] {
+			return keys[i][1] < keys[j][1]
+		}
+		return keys[i][0] < keys[j][0]
+	})
+	for _, k := range keys {
+		b.WriteString(name + `{command="` + escapeLabel(k[0]) + `",` + label + `="` + escapeLabel(k[1]) + `"} ` + strconv.FormatUint(counts[k], 10) + "\n")
+	}
+}
+
+// Metrics is used to get the metrics in the Prometheus text format.
+func (p *PrometheusHooks) Metrics() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	b := &strings.Builder{}
+	b.WriteString("# HELP gommand_prefix_matches_total The number of messages which used the prefix.\n# TYPE gommand_prefix_matches_total counter\n")
+	b.WriteString("gommand_prefix_matches_total " + strconv.FormatUint(p.prefixMatches, 10) + "\n")
+	writeCommandCounter(b, "gommand_commands_resolved_total", "The number of times each command was found.", p.resolved)
+	writeCommandCounter(b, "gommand_permission_denied_total", "The number of times users did not have permission to run each command.", p.permissionDenied)
+	writeCommandCounter(b, "gommand_cooldown_hits_total", "The number of times each command was on cooldown.", p.cooldownHits)
+	writeLabelledCounter(b, "gommand_commands_total", "The number of times each command finished, by result.", "result", p.finished)
+	writeLabelledCounter(b, "gommand_command_errors_total", "The number of errors returned by each command, by error class.", "class", p.errors)
+
+	b.WriteString("# HELP gommand_command_duration_seconds How long each command took to run.\n# TYPE gommand_command_duration_seconds histogram\n")
+	keys := make([]string, 0, len(p.latencies))
+	for k := range p.latencies {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h := p.latencies[k]
+		label := `command="` + escapeLabel(k) + `"`
+		for i, v := range p.buckets {
+			b.WriteString(`gommand_command_duration_seconds_bucket{` + label + `,le="` + formatFloat(v) + `"} ` + strconv.FormatUint(h.counts[i], 10) + "\n")
+		}
+		b.WriteString(`gommand_command_duration_seconds_bucket{` + label + `,le="+Inf"} ` + strconv.FormatUint(h.count, 10) + "\n")
+		b.WriteString(`gommand_command_duration_seconds_sum{` + label + `} ` + formatFloat(h.sum) + "\n")
+		b.WriteString(`gommand_command_duration_seconds_count{` + label + `} ` + strconv.FormatUint(h.count, 10) + "\n")
+	}
+	return b.String()
+}
+
+// ServeHTTP is used to serve the metrics in the Prometheus text format.
+func (p *PrometheusHooks) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(p.Metrics()))
+}
@@ -0,0 +1,234 @@
+package gommand
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// The emojis used for choices when reactions are used.
+var choiceEmojis = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣", "🔟"}
+
+// PromptOptions are the options which can be set for a prompt. All fields are optional.
+type PromptOptions struct {
+	// Timeout is how long the user has to answer each question. 0 will default to 1 minute.
+	Timeout time.Duration
+
+	// CancelKeywords are the keywords which will cancel the prompt when typed, including when reactions are used. nil will default to "cancel".
+	CancelKeywords []string
+
+	// UseReactions defines if reactions should be used for answers where possible rather than typed replies.
+	UseReactions bool
+}
+
+// Gets the timeout for the prompt.
+func (o *PromptOptions) timeout() time.Duration {
+	if o == nil || o.Timeout == 0 {
+		return time.Minute
+	}
+	return o.Timeout
+}
+
+// Checks if the content is a cancel keyword.
+func (o *PromptOptions) isCancel(content string) bool {
+	keywords := []string{"cancel"}
+	if o != nil && o.CancelKeywords != nil {
+		keywords = o.CancelKeywords
+	}
+	for _, v := range keywords {
+		if strings.EqualFold(content, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if reactions should be used.
+func (o *PromptOptions) useReactions() bool {
+	return o != nil && o.UseReactions
+}
+
+// Asks a question and waits for a typed reply which the transformer accepts.
+// If the transformer errors, the error is sent and the user can try again until the timeout.
+func (c *Context) askTyped(question string, transformer func(ctx *Context, Arg string) (interface{}, error), options *PromptOptions) (interface{}, error) {
+	if c.capture != nil {
+		// The user would not see the question.
+		return nil, cannotProduceText(c)
+	}
+	if _, err := c.Reply(question); err != nil {
+		return nil, err
+	}
+	waitCtx, cancel := withClockTimeout(c.Router.clock(), context.Background(), options.timeout())
+	defer cancel()
+	for {
+		msg := c.WaitForMessage(waitCtx, func(_ disgord.Session, msg *disgord.Message) bool {
+			return msg.ChannelID == c.Message.ChannelID && msg.Author.ID == c.Message.Author.ID
+		})
+		if msg == nil {
+			return nil, &PromptTimeout{err: c.Translate(MessagePromptTimeout)}
+		}
+		content := strings.TrimSpace(msg.Content)
+		if options.isCancel(content) {
+			return nil, &PromptCancelled{err: c.Translate(MessagePromptCancelled)}
+		}
+		res, err := transformer(c, content)
+		if err == nil {
+			return res, nil
+		}
+		if _, err = c.Reply(c.Translate(MessagePromptTryAgain, "error", err.Error())); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Asks a question with reactions and waits for the user to click one of them or type a cancel keyword.
+// The index of the emoji clicked is returned.
+func (c *Context) askReactions(question string, emojis []string, options *PromptOptions) (int, error) {
+	if c.capture != nil {
+		return 0, cannotProduceText(c)
+	}
+	msg, err := c.Reply(question)
+	if err != nil {
+		return 0, err
+	}
+	msgRef := c.Session.Channel(msg.ChannelID).Message(msg.ID)
+	defer func() { _ = msgRef.DeleteAllReactions() }()
+	for _, v := range emojis {
+		if err = msgRef.Reaction(v).Create(); err != nil {
+			return 0, err
+		}
+	}
+	waitCtx, cancel := withClockTimeout(c.Router.clock(), context.Background(), options.timeout())
+	defer cancel()
+	index := -1
+	evt, err := c.WaitManager.WaitForAny(waitCtx, func(_ disgord.Session, evt interface{}) bool {
+		switch e := evt.(type) {
+		case *disgord.MessageReactionAdd:
+			if e.MessageID != msg.ID || e.UserID != c.Message.Author.ID || e.PartialEmoji == nil {
+				return false
+			}
+			for i, v := range emojis {
+				if v == e.PartialEmoji.Name {
+					index = i
+					return true
+				}
+			}
+		case *disgord.MessageCreate:
+			// The user can still type a cancel keyword.
+			m := e.Message
+			return m.ChannelID == c.Message.ChannelID && m.Author.ID == c.Message.Author.ID && options.isCancel(strings.TrimSpace(m.Content))
+		}
+		return false
+	}, disgord.EvtMessageReactionAdd, disgord.EvtMessageCreate)
+	if err != nil {
+		return 0, err
+	}
+	if evt == nil {
+		return 0, &PromptTimeout{err: c.Translate(MessagePromptTimeout)}
+	}
+	if _, ok := evt.(*disgord.MessageCreate); ok {
+		return 0, &PromptCancelled{err: c.Translate(MessagePromptCancelled)}
+	}
+	return index, nil
+}
+
+// Confirm is used to ask the user a yes or no question, returning their answer.
+// If the user cancels or doesn't answer in time, a PromptCancelled or PromptTimeout error is returned.
+func (c *Context) Confirm(Question string, Options *PromptOptions) (bool, error) {
+	if Options.useReactions() {
+		index, err := c.askReactions(Question, []string{"✅", "❌"}, Options)
+		if err != nil {
+			return false, err
+		}
+		return index == 0, nil
+	}
+	res, err := c.askTyped(c.Translate(MessagePromptYesNo, "question", Question), BooleanTransformer, Options)
+	if err != nil {
+		return false, err
+	}
+	return res.(bool), nil
+}
+
+// Choose is used to ask the user to pick one of the choices, returning the index of the choice.
+// When reactions are used, a maximum of 10 choices are supported.
+// If the user cancels or doesn't answer in time, a PromptCancelled or PromptTimeout error is returned.
+func (c *Context) Choose(Question string, Choices []string, Options *PromptOptions) (int, error) {
+	if Options.useReactions() && len(Choices) <= len(choiceEmojis) {
+		lines := make([]string, len(Choices))
+		for i, v := range Choices {
+			lines[i] = choiceEmojis[i] + " " + v
+		}
+		return c.askReactions(Question+"\n"+strings.Join(lines, "\n"), choiceEmojis[:len(Choices)], Options)
+	}
+	lines := make([]string, len(Choices))
+	for i, v := range Choices {
+		lines[i] = strconv.Itoa(i+1) + ". " + v
+	}
+	res, err := c.askTyped(Question+"\n"+strings.Join(lines, "\n"), func(ctx *Context, Arg string) (interface{}, error) {
+		if i, err := strconv.Atoi(Arg); err == nil && i >= 1 && i <= len(Choices) {
+			return i - 1, nil
+		}
+		for i, v := range Choices {
+			if strings.EqualFold(v, Arg) {
+				return i, nil
+			}
+		}
+		return nil, &InvalidTransformation{Description: ctx.Translate(MessagePromptInvalidChoice)}
+	}, Options)
+	if err != nil {
+		return 0, err
+	}
+	return res.(int), nil
+}
+
+// FormQuestion is a question which is asked within a form.
+type FormQuestion struct {
+	// Name is the key which the answer will have in the results.
+	Name string
+
+	// Question is the question which will be sent to the user.
+	Question string
+
+	// Transformer is used to validate and transform the answer. This can be any argument transformer.
+	Transformer func(ctx *Context, Arg string) (interface{}, error)
+}
+
+// Form is used to ask the user a series of questions, validating each answer.
+type Form struct {
+	// Questions are the questions which will be asked in order.
+	Questions []*FormQuestion
+
+	// Options are the prompt options for each question. This can be nil.
+	Options *PromptOptions
+}
+
+// NewForm is used to create a new form.
+func NewForm(Options *PromptOptions) *Form {
+	return &Form{Questions: []*FormQuestion{}, Options: Options}
+}
+
+// Ask is used to add a question to the form. If the transformer is nil, StringTransformer will be used.
+func (f *Form) Ask(Name, Question string, Transformer func(ctx *Context, Arg string) (interface{}, error)) *Form {
+	if Transformer == nil {
+		Transformer = StringTransformer
+	}
+	f.Questions = append(f.Questions, &FormQuestion{Name: Name, Question: Question, Transformer: Transformer})
+	return f
+}
+
+// Run is used to ask the user each question in the form, returning the transformed answers by name.
+// If the user cancels or doesn't answer in time, a PromptCancelled or PromptTimeout error is returned.
+func (f *Form) Run(ctx *Context) (map[string]interface{}, error) {
+	answers := make(map[string]interface{}, len(f.Questions))
+	for _, v := range f.Questions {
+		res, err := ctx.askTyped(v.Question, v.Transformer, f.Options)
+		if err != nil {
+			return nil, err
+		}
+		answers[v.Name] = res
+	}
+	return answers, nil
+}
@@ -0,0 +1,244 @@
+package gommand
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// TestPromptOptions is used to test the prompt option defaults and the form builder.
+func TestPromptOptions(t *testing.T) {
+	var o *PromptOptions
+	if o.timeout() != time.Minute || !o.isCancel("CANCEL") || o.useReactions() {
+		t.Fatal("invalid nil prompt option defaults")
+	}
+	o = &PromptOptions{Timeout: time.Second, CancelKeywords: []string{"stop"}}
+	if o.timeout() != time.Second || o.isCancel("cancel") || !o.isCancel("Stop") {
+		t.Fatal("prompt options not respected")
+	}
+
+	f := NewForm(nil).Ask("name", "What is your name?", nil).Ask("age", "How old are you?", UIntTransformer)
+	if len(f.Questions) != 2 || f.Questions[0].Transformer == nil || f.Questions[1].Name != "age" {
+		t.Fatal("form questions not added correctly")
+	}
+}
+
+// Used as a session which records the messages sent by prompts and ignores reactions.
+type promptSession struct {
+	*testWaitSession
+	sent chan string
+}
+
+func (s *promptSession) SendMsg(ChannelID disgord.Snowflake, data ...interface{}) (*disgord.Message, error) {
+	s.sent <- data[0].(string)
+	return &disgord.Message{ID: 100, ChannelID: ChannelID}, nil
+}
+
+func (s *promptSession) Channel(disgord.Snowflake) disgord.ChannelQueryBuilder {
+	return promptChannel{}
+}
+
+type promptChannel struct {
+	disgord.ChannelQueryBuilder
+}
+
+func (promptChannel) Message(disgord.Snowflake) disgord.MessageQueryBuilder {
+	return promptMessage{}
+}
+
+type promptMessage struct {
+	disgord.MessageQueryBuilder
+}
+
+func (promptMessage) Reaction(interface{}) disgord.ReactionQueryBuilder {
+	return promptReaction{}
+}
+
+func (promptMessage) DeleteAllReactions(...disgord.Flag) error {
+	return nil
+}
+
+type promptReaction struct {
+	disgord.ReactionQueryBuilder
+}
+
+func (promptReaction) Create(...disgord.Flag) error {
+	return nil
+}
+
+// Used to run a prompt against a fake session and answer it.
+type promptTest struct {
+	t       *testing.T
+	s       *promptSession
+	ctx     *Context
+	waiters map[*eventWaiter]bool
+}
+
+// Creates the context which the prompt is ran in.
+func newPromptTest(t *testing.T) *promptTest {
+	s := &promptSession{testWaitSession: newTestWaitSession(), sent: make(chan string, 10)}
+	msg := mockMessage("")
+	msg.ChannelID = 2
+	msg.Author.ID = 3
+	ctx := &Context{Router: NewRouter(&RouterConfig{}), Session: s, Message: msg}
+	ctx.WaitManager = &WaitManager{ctx: ctx}
+	return &promptTest{t: t, s: s, ctx: ctx, waiters: map[*eventWaiter]bool{}}
+}
+
+// Runs the prompt in the background, returning a channel which the error is sent to.
+func (p *promptTest) run(f func(ctx *Context) error) <-chan error {
+	res := make(chan error, 1)
+	go func() { res <- f(p.ctx) }()
+	return res
+}
+
+// Waits for the prompt to send a message and returns it.
+func (p *promptTest) expect() string {
+	p.t.Helper()
+	select {
+	case msg := <-p.s.sent:
+		return msg
+	case <-time.After(time.Second):
+		p.t.Fatal("the prompt did not send a message")
+		return ""
+	}
+}
+
+// Waits until a waiter which has not been answered is waiting for the event, and then dispatches the event.
+// Waiters from earlier answers may not have been removed yet, so they are remembered and skipped.
+func (p *promptTest) answer(evtName string, evt interface{}) {
+	p.t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		found := false
+		waitDispatchersLock.Lock()
+		if d := waitDispatchers[p.s]; d != nil {
+			d.lock.RLock()
+			for w := range d.waiters[evtName] {
+				if !p.waiters[w] {
+					p.waiters[w] = true
+					found = true
+				}
+			}
+			d.lock.RUnlock()
+		}
+		waitDispatchersLock.Unlock()
+		if found {
+			break
+		}
+		if time.Now().After(deadline) {
+			p.t.Fatal("the prompt is not waiting for", evtName)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	p.s.dispatch(evtName, evt)
+}
+
+// Sends a message from the user in the channel of the prompt.
+func (p *promptTest) answerMessage(content string) {
+	p.t.Helper()
+	p.answer(disgord.EvtMessageCreate, &disgord.MessageCreate{Message: &disgord.Message{
+		ChannelID: 2, Author: &disgord.User{ID: 3}, Content: content,
+	}})
+}
+
+// Waits for the prompt to return.
+func (p *promptTest) result(res <-chan error) error {
+	p.t.Helper()
+	select {
+	case err := <-res:
+		return err
+	case <-time.After(time.Second):
+		p.t.Fatal("the prompt did not return")
+		return nil
+	}
+}
+
+// TestPromptTyped is used to test answering, retrying and cancelling typed prompts.
+func TestPromptTyped(t *testing.T) {
+	// Invalid answers should make the user try again.
+	p := newPromptTest(t)
+	var answer int
+	res := p.run(func(ctx *Context) (err error) {
+		answer, err = ctx.Choose("Pick one", []string{"red", "blue"}, nil)
+		return
+	})
+	p.expect()
+	p.answerMessage("green")
+	if msg := p.expect(); msg != p.ctx.Translate(MessagePromptTryAgain, "error", p.ctx.Translate(MessagePromptInvalidChoice)) {
+		t.Fatal("unexpected try again message:", msg)
+	}
+	p.answerMessage("Blue")
+	if err := p.result(res); err != nil || answer != 1 {
+		t.Fatal("unexpected answer:", answer, err)
+	}
+
+	// Cancel keywords should cancel the prompt.
+	p = newPromptTest(t)
+	res = p.run(func(ctx *Context) error {
+		_, err := NewForm(&PromptOptions{CancelKeywords: []string{"stop"}}).Ask("name", "What is your name?", nil).Run(ctx)
+		return err
+	})
+	p.expect()
+	p.answerMessage(" STOP ")
+	if _, ok := p.result(res).(*PromptCancelled); !ok {
+		t.Fatal("the prompt was not cancelled")
+	}
+
+	// Not answering in time should time out.
+	p = newPromptTest(t)
+	res = p.run(func(ctx *Context) error {
+		_, err := ctx.Confirm("Are you sure?", &PromptOptions{Timeout: time.Millisecond})
+		return err
+	})
+	p.expect()
+	if _, ok := p.result(res).(*PromptTimeout); !ok {
+		t.Fatal("the prompt did not time out")
+	}
+}
+
+// TestPromptReactions is used to test answering and cancelling prompts which use reactions.
+func TestPromptReactions(t *testing.T) {
+	options := &PromptOptions{UseReactions: true}
+
+	// Reactions which are not choices should be ignored.
+	p := newPromptTest(t)
+	var answer int
+	res := p.run(func(ctx *Context) (err error) {
+		answer, err = ctx.Choose("Pick one", []string{"red", "blue"}, options)
+		return
+	})
+	p.expect()
+	p.answer(disgord.EvtMessageReactionAdd, &disgord.MessageReactionAdd{MessageID: 100, UserID: 3, PartialEmoji: &disgord.Emoji{Name: "👍"}})
+	p.s.dispatch(disgord.EvtMessageReactionAdd, &disgord.MessageReactionAdd{MessageID: 100, UserID: 3, PartialEmoji: &disgord.Emoji{Name: "2️⃣"}})
+	if err := p.result(res); err != nil || answer != 1 {
+		t.Fatal("unexpected answer:", answer, err)
+	}
+
+	// Typing a cancel keyword should cancel the prompt.
+	p = newPromptTest(t)
+	res = p.run(func(ctx *Context) error {
+		_, err := ctx.Confirm("Are you sure?", options)
+		return err
+	})
+	p.expect()
+	p.answerMessage("yes")
+	p.s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{Message: &disgord.Message{
+		ChannelID: 2, Author: &disgord.User{ID: 3}, Content: "cancel",
+	}})
+	if _, ok := p.result(res).(*PromptCancelled); !ok {
+		t.Fatal("the prompt was not cancelled")
+	}
+
+	// Not answering in time should time out.
+	p = newPromptTest(t)
+	res = p.run(func(ctx *Context) error {
+		_, err := ctx.Confirm("Are you sure?", &PromptOptions{UseReactions: true, Timeout: time.Millisecond})
+		return err
+	})
+	p.expect()
+	if _, ok := p.result(res).(*PromptTimeout); !ok {
+		t.Fatal("the prompt did not time out")
+	}
+}
@@ -31,3 +31,34 @@ func TestRemainder(t *testing.T) {
 	})
 	r.CommandProcessor(nil, 0, mockMessage("%remainder   \"hello\""), true)
 }
+
+// TestMissingRemainder is used to test a required remainder argument which isn't given.
+func TestMissingRemainder(t *testing.T) {
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+	})
+	r.SetCommand(&Command{
+		Name: "remainder",
+		ArgTransformers: []ArgTransformer{
+			{
+				Function:  StringTransformer,
+				Remainder: true,
+			},
+		},
+		Function: func(ctx *Context) error {
+			t.Log("The command should not run.")
+			t.FailNow()
+			return nil
+		},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	r.CommandProcessor(nil, 0, mockMessage("%remainder  "), true)
+	if e, ok := lastErr.(*InvalidArgCount); !ok || e.Error() != "A required argument is missing." {
+		t.Log("Unexpected error:", lastErr)
+		t.FailNow()
+	}
+}
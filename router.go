@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 )
 
 // PrefixCheck is the type for a function to check the prefix. true here means the prefix is there and was read.
@@ -46,6 +47,65 @@ type RouterConfig struct {
 	Cooldown             Cooldown
 	GetState             GetState
 
+	// AroundMiddleware is used to wrap every command function. This is the outermost around middleware. This can be nil.
+	AroundMiddleware []AroundMiddleware
+
+	// AfterHooks are called after every command function has ran. These are called after any category or command after hooks. This can be nil.
+	AfterHooks []AfterHook
+
+	// MenuStorageAdapter is used to persist menus with a MenuID set so that they survive restarts. This can be nil.
+	MenuStorageAdapter MenuStorageAdapter
+
+	// Clock is used to get the time and schedule functions for cooldowns, menu lifetimes, waits and message cache expiry. nil will default to SystemClock.
+	Clock Clock
+
+	// Hooks is used to observe what the router does while processing a message. This can be nil.
+	Hooks Hooks
+
+	// Tracer is used to create spans for each part of processing a message. This can be nil.
+	Tracer Tracer
+
+	// Logger is used for structured logging. If this is nil, unhandled errors are logged through the session logger.
+	Logger Logger
+
+	// OwnerIDs is the IDs of the bot owners. This is used by the BotOwnerOnly permission validator and can be nil.
+	OwnerIDs []disgord.Snowflake
+
+	// OwnerResolver is used to get more bot owners the first time they are needed, such as ApplicationOwners. This can be nil.
+	OwnerResolver OwnerResolver
+
+	// Developer is used to add the built-in developer commands which only the bot owners can use. nil will not add these commands.
+	Developer *DeveloperConfig
+
+	// Catalog is used to translate the built-in messages. nil will use DefaultMessages.
+	Catalog Catalog
+
+	// LocaleResolver is used to get the locale of each command invocation (such as from a guild setting) which messages are translated into. This can be nil.
+	LocaleResolver LocaleResolver
+
+	// UsageStore is used to record every time a command is ran, allowing for usage statistics. This can be nil.
+	UsageStore UsageStore
+
+	// Stats is used to add the built-in stats command which shows the usage statistics from the UsageStore. nil will not add this command.
+	Stats *StatsConfig
+
+	// Shortcuts is used to allow guild admins to add shortcuts which run commands with pre-filled arguments. nil will disable shortcuts.
+	Shortcuts *ShortcutsConfig
+
+	// CustomCommands is used to allow guild admins to add custom commands which reply with a template. nil will disable this.
+	// Note that this sets the CustomCommandsHandler of the router.
+	CustomCommands *CustomCommandsConfig
+
+	// Chaining is used to allow users to run several commands in one message, either one after another ("!a ; !b") or by piping the text of one into the next ("!a | !b"). nil will disable this.
+	Chaining *ChainingConfig
+
+	// StrictCommands is used to make SetCommand and ReplaceCommands panic rather than logging or returning an error when a name or alias is used by a different command or the argument transformers are invalid.
+	// This is useful to catch these problems when the bot starts.
+	StrictCommands bool
+
+	// Help is used to configure the built-in help command. nil will install the help command with the default config.
+	Help *HelpConfig
+
 	// The number if message pads which will be created in memory to allow for quicker parsing.
 	// Please set this to -1 if you do not want any, 0 will default to 100.
 	MessagePads int
@@ -54,18 +114,43 @@ type RouterConfig struct {
 // Router defines the command router which is being used.
 // Please call NewRouter to initialise this rather than creating a new struct.
 type Router struct {
-	PrefixCheck           PrefixCheck           `json:"-"`
-	CustomCommandsHandler CustomCommandsHandler `json:"-"`
-	cmds                  map[string]CommandInterface
-	botUsers              map[uint]*disgord.User
-	cmdLock               *sync.RWMutex
-	errorHandlers         []ErrorHandler
-	permissionValidators  []PermissionValidator
-	middleware            []Middleware
-	parserManager         *fastparse.ParserManager
-	MessageCacheHandler   *MessageCacheHandler
-	Cooldown              Cooldown
-	GetState              GetState
+	PrefixCheck                 PrefixCheck           `json:"-"`
+	CustomCommandsHandler       CustomCommandsHandler `json:"-"`
+	cmds                        *commandTable
+	builtinCmds                 []CommandInterface
+	strictCommands              bool
+	chaining                    *ChainingConfig
+	botUsers                    map[uint]*disgord.User
+	cmdLock                     *sync.RWMutex
+	errorHandlers               []ErrorHandler
+	permissionValidators        []PermissionValidator
+	middleware                  []Middleware
+	aroundMiddleware            []AroundMiddleware
+	afterHooks                  []AfterHook
+	parserManager               *fastparse.ParserManager
+	MessageCacheHandler         *MessageCacheHandler
+	Cooldown                    Cooldown
+	GetState                    GetState
+	MenuStorageAdapter          MenuStorageAdapter
+	menuBuilders                map[string]MenuBuilder
+	menuBuildersLock            *sync.RWMutex
+	Clock                       Clock
+	Hooks                       Hooks
+	Tracer                      Tracer
+	Logger                      Logger
+	Catalog                     Catalog
+	LocaleResolver              LocaleResolver
+	UsageStore                  UsageStore
+	ShortcutStorageAdapter      ShortcutStorageAdapter
+	CustomCommandStorageAdapter CustomCommandStorageAdapter
+	ownerIDs                    []disgord.Snowflake
+	ownerResolver               OwnerResolver
+	ownersResolved              bool
+	ownersResolving             bool
+	ownersFailures              int
+	ownersRetry                 time.Time
+	ownersLock                  *sync.Mutex
+	started                     time.Time
 }
 
 // NewRouter creates a new command Router.
@@ -77,6 +162,11 @@ func NewRouter(Config *RouterConfig) *Router {
 		}
 	}
 
+	// Default to the system clock.
+	if Config.Clock == nil {
+		Config.Clock = SystemClock
+	}
+
 	// Set the Router.
 	if Config.MessagePads == 0 {
 		Config.MessagePads = 100
@@ -85,20 +175,79 @@ func NewRouter(Config *RouterConfig) *Router {
 	}
 	r := &Router{
 		PrefixCheck:          Config.PrefixCheck,
-		cmds:                 map[string]CommandInterface{},
+		cmds:                 newCommandTable(),
+		strictCommands:       Config.StrictCommands,
+		chaining:             Config.Chaining,
 		cmdLock:              &sync.RWMutex{},
 		errorHandlers:        Config.ErrorHandlers,
 		permissionValidators: Config.PermissionValidators,
 		middleware:           Config.Middleware,
+		aroundMiddleware:     Config.AroundMiddleware,
+		afterHooks:           Config.AfterHooks,
 		MessageCacheHandler:  Config.MessageCacheHandler,
 		Cooldown:             Config.Cooldown,
 		botUsers:             map[uint]*disgord.User{},
 		parserManager:        fastparse.NewParserManager(2000, Config.MessagePads),
 		GetState:             Config.GetState,
+		MenuStorageAdapter:   Config.MenuStorageAdapter,
+		menuBuilders:         map[string]MenuBuilder{},
+		menuBuildersLock:     &sync.RWMutex{},
+		Clock:                Config.Clock,
+		Hooks:                Config.Hooks,
+		Tracer:               Config.Tracer,
+		Logger:               Config.Logger,
+		Catalog:              Config.Catalog,
+		LocaleResolver:       Config.LocaleResolver,
+		UsageStore:           Config.UsageStore,
+		ownerIDs:             append([]disgord.Snowflake{}, Config.OwnerIDs...),
+		ownerResolver:        Config.OwnerResolver,
+		ownersLock:           &sync.Mutex{},
+		started:              Config.Clock.Now(),
 	}
 
 	// Set the help command.
-	r.SetCommand(defaultHelpCommand())
+	help := Config.Help
+	if help == nil {
+		help = &HelpConfig{}
+	}
+	if !help.Disabled {
+		r.setBuiltinCommand(defaultHelpCommand(help))
+	}
+
+	// Add the developer commands if they are wanted.
+	if Config.Developer != nil {
+		addDeveloperCommands(r, Config.Developer)
+	}
+
+	// Add the stats command if it is wanted.
+	if Config.Stats != nil {
+		r.setBuiltinCommand(statsCommand(Config.Stats))
+	}
+
+	// Set up the shortcuts if they are wanted.
+	if Config.Shortcuts != nil {
+		r.ShortcutStorageAdapter = Config.Shortcuts.StorageAdapter
+		if r.ShortcutStorageAdapter == nil {
+			r.ShortcutStorageAdapter = &InMemoryShortcutStorageAdapter{}
+		}
+		r.ShortcutStorageAdapter.Init()
+		if !Config.Shortcuts.DisableCommands {
+			r.setBuiltinCommand(shortcutCommands(Config.Shortcuts))
+		}
+	}
+
+	// Set up the custom commands if they are wanted.
+	if Config.CustomCommands != nil {
+		r.CustomCommandStorageAdapter = Config.CustomCommands.StorageAdapter
+		if r.CustomCommandStorageAdapter == nil {
+			r.CustomCommandStorageAdapter = &InMemoryCustomCommandStorageAdapter{}
+		}
+		r.CustomCommandStorageAdapter.Init()
+		r.CustomCommandsHandler = customCommandsHandler
+		if !Config.CustomCommands.DisableCommands {
+			r.setBuiltinCommand(customCommandCommands(Config.CustomCommands))
+		}
+	}
 
 	// If deleted message handler isn't nil, initialise the storage adapter.
 	if r.MessageCacheHandler != nil {
@@ -108,6 +257,16 @@ func NewRouter(Config *RouterConfig) *Router {
 		r.MessageCacheHandler.MessageCacheStorageAdapter.Init()
 	}
 
+	// If the menu storage adapter isn't nil, initialise it.
+	if r.MenuStorageAdapter != nil {
+		r.MenuStorageAdapter.Init()
+	}
+
+	// If the usage store isn't nil, initialise it.
+	if r.UsageStore != nil {
+		r.UsageStore.Init()
+	}
+
 	// Return the router.
 	return r
 }
@@ -124,8 +283,8 @@ func (r *Router) errorHandler(ctx *Context, err error) {
 		}
 	}
 
-	// No error handlers, go straight to console logging.
-	ctx.Session.Logger().Error(err)
+	// No error handlers handled this, go straight to logging.
+	r.logUnhandledError(ctx, err)
 }
 
 // AddErrorHandler is used to add a error handler to the Router.
@@ -140,41 +299,129 @@ func (r *Router) AddErrorHandler(Handler ErrorHandler) {
 // GetCommand is used to get a command from the Router if it exists.
 // If the command doesn't exist, this will be a nil pointer.
 func (r *Router) GetCommand(Name string) CommandInterface {
+	return r.GetLocalisedCommand("", Name)
+}
+
+// GetLocalisedCommand is used to get a command from the Router by its name in the locale, falling back to the name of the command.
+// If the command doesn't exist, this will be a nil pointer.
+func (r *Router) GetLocalisedCommand(Locale, Name string) CommandInterface {
+	r.cmdLock.RLock()
+	cmd := r.cmds.get(Locale, Name)
+	r.cmdLock.RUnlock()
+	return cmd
+}
+
+// Gets a command by its name or its name in any locale. This is used to check names which are used in every locale, such as custom commands.
+func (r *Router) getCommandInAnyLocale(Name string) CommandInterface {
 	r.cmdLock.RLock()
-	cmd := r.cmds[strings.ToLower(Name)]
+	cmd := r.cmds.getAnyLocale(Name)
 	r.cmdLock.RUnlock()
 	return cmd
 }
 
-// SetCommand is used to set a command.
+// Initialises the cooldown of the command and any sub-commands. This is done once the command is set so a command which fails to be set keeps its cooldown state.
+func initCooldowns(c CommandInterface) {
+	if cooldown := c.GetCooldown(); cooldown != nil {
+		cooldown.Init()
+	}
+	if g, ok := c.(*CommandGroup); ok {
+		g.initCooldowns()
+	}
+}
+
+// SetCommand is used to set a command. If there is already a command with the same name, it is replaced along with all of its aliases.
+// If a name or alias of the command is used by a different command, or the argument transformers of the command (or any sub-commands) are in an invalid order, the command is not set.
+// The error is logged with the Logger if one is set, otherwise it is dropped. If StrictCommands is set in the config, this panics instead. Use TrySetCommand to get the error.
 func (r *Router) SetCommand(c CommandInterface) {
+	err := r.commandsError(r.TrySetCommand(c))
+	if err != nil && r.Logger != nil {
+		r.Logger.Error("Failed to set the command.", LogFields{"command": c.GetName(), "error": err.Error()})
+	}
+}
+
+// TrySetCommand is used to set a command, returning an error if it cannot be set. If there is already a command with the same name, it is replaced along with all of its aliases.
+// If a name or alias of the command (or of a sub-command within its group) is used by a different command, a *CommandConflict error is returned and the command is not set.
+// If the argument transformers of the command (or any sub-commands) are in an invalid order, a *InvalidArgTransformers error is returned and the command is not set.
+// The cooldowns of the command are only initialised if it is set.
+func (r *Router) TrySetCommand(c CommandInterface) error {
 	c.Init()
-	cooldown := c.GetCooldown()
-	if cooldown != nil {
-		cooldown.Init()
+	if err := checkCommand(c.GetName(), c); err != nil {
+		return err
 	}
 	r.cmdLock.Lock()
-	name := strings.ToLower(c.GetName())
-	r.cmds[name] = c
-	if c.GetAliases() != nil {
-		for _, v := range c.GetAliases() {
-			v = strings.ToLower(v)
-			r.cmds[v] = c
-		}
+	t := r.cmds.clone()
+	err := t.add(c)
+	if err == nil {
+		r.cmds = t
 	}
 	r.cmdLock.Unlock()
+	if err == nil {
+		initCooldowns(c)
+	}
+	return err
+}
+
+// Panics with the error if the commands are strict, otherwise returns it.
+func (r *Router) commandsError(err error) error {
+	if err != nil && r.strictCommands {
+		panic(err)
+	}
+	return err
+}
+
+// Sets a built-in command which is kept when the commands are replaced.
+func (r *Router) setBuiltinCommand(c CommandInterface) {
+	if r.commandsError(r.TrySetCommand(c)) == nil {
+		r.cmdLock.Lock()
+		r.builtinCmds = append(r.builtinCmds, c)
+		r.cmdLock.Unlock()
+	}
 }
 
 // RemoveCommand is used to remove a command from the Router.
+// This removes the command with the same name, along with any aliases which belong to it.
 func (r *Router) RemoveCommand(c CommandInterface) {
 	r.cmdLock.Lock()
-	delete(r.cmds, strings.ToLower(c.GetName()))
-	if c.GetAliases() != nil {
-		for _, v := range c.GetAliases() {
-			delete(r.cmds, strings.ToLower(v))
+	t := r.cmds.clone()
+	t.remove(c)
+	r.cmds = t
+	r.cmdLock.Unlock()
+}
+
+// ReplaceCommands is used to replace all of the commands in the Router at once.
+// The built-in help and developer commands are kept unless a command in the set has the same name.
+// The new commands are swapped in together, meaning commands which are running or being looked up will never see a mix of the old and new commands.
+// If any of the names or aliases conflict, a *CommandConflict error is returned and the commands are not changed.
+// The commands are also not changed if the argument transformers of any commands are in an invalid order, returning a *InvalidArgTransformers error.
+// If StrictCommands is set in the config, this panics instead.
+func (r *Router) ReplaceCommands(Commands []CommandInterface) error {
+	for _, v := range Commands {
+		v.Init()
+		if err := checkCommand(v.GetName(), v); err != nil {
+			return r.commandsError(err)
+		}
+	}
+	r.cmdLock.Lock()
+	t := newCommandTable()
+	for _, v := range r.builtinCmds {
+		_ = t.add(v)
+	}
+	var err error
+	for _, v := range Commands {
+		if err = t.add(v); err != nil {
+			break
 		}
 	}
+	if err == nil {
+		r.cmds = t
+	}
 	r.cmdLock.Unlock()
+	if err == nil {
+		for _, v := range Commands {
+			initCooldowns(v)
+		}
+	}
+	return r.commandsError(err)
 }
 
 // GetAllCommands is used to get all of the commands.
@@ -184,8 +431,8 @@ func (r *Router) GetAllCommands() []CommandInterface {
 
 	// Get the command count.
 	count := 0
-	for k, v := range r.cmds {
-		if k == v.GetName() {
+	for k, v := range r.cmds.cmds {
+		if k == strings.ToLower(v.GetName()) {
 			count++
 		}
 	}
@@ -197,8 +444,8 @@ func (r *Router) GetAllCommands() []CommandInterface {
 	index := 0
 
 	// Go through each command and add it to the array.
-	for k, v := range r.cmds {
-		if k == v.GetName() {
+	for k, v := range r.cmds.cmds {
+		if k == strings.ToLower(v.GetName()) {
 			a[index] = v
 			index++
 		}
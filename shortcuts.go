@@ -0,0 +1,179 @@
+package gommand
+
+import (
+	"strings"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Shortcut is a word which guild admins have mapped to a command with pre-filled arguments, such as "mute10" running "mute {args} 10m".
+type Shortcut struct {
+	// Name is the word which is used to run the shortcut. This is lowercase.
+	Name string `json:"name"`
+
+	// Command is the path of the command which is ran, including any command groups (for example, "config prefix").
+	Command string `json:"command"`
+
+	// Args are the pre-filled arguments. "{args}" is replaced with the arguments the user gave, and if it isn't present, they are added to the end.
+	Args string `json:"args"`
+}
+
+// Expand is used to get the command and arguments which are ran when the shortcut is used with the arguments given.
+func (s *Shortcut) Expand(Args string) string {
+	Args = strings.Trim(Args, " ")
+	x := s.Args
+	if strings.Contains(x, "{args}") {
+		x = strings.Replace(x, "{args}", Args, -1)
+	} else if Args != "" {
+		x += " " + Args
+	}
+	return strings.Join(strings.Fields(s.Command+" "+x), " ")
+}
+
+// ShortcutStorageAdapter is the interface which is used for storing the shortcuts of each guild.
+type ShortcutStorageAdapter interface {
+	// Called when the router is created.
+	Init()
+
+	// Get is used to get a shortcut by its lowercase name. If it doesn't exist, this should be nil.
+	Get(GuildID disgord.Snowflake, Name string) (*Shortcut, error)
+
+	// Set is used to add or replace a shortcut.
+	Set(GuildID disgord.Snowflake, Value *Shortcut) error
+
+	// Delete is used to delete a shortcut by its lowercase name.
+	Delete(GuildID disgord.Snowflake, Name string) error
+
+	// List is used to get all of the shortcuts of a guild.
+	List(GuildID disgord.Snowflake) ([]*Shortcut, error)
+}
+
+// Used to check a shortcut can be created with the name, returning the message to send if it can't. A shortcut cannot hide a command or custom command.
+func checkShortcutName(ctx *Context, Name string) (string, error) {
+	if ctx.Router.getCommandInAnyLocale(Name) != nil {
+		return ctx.Translate(MessageShortcutCommandConflict, "name", Name), nil
+	}
+	if ctx.Router.CustomCommandStorageAdapter != nil {
+		c, err := ctx.Router.CustomCommandStorageAdapter.Get(ctx.Message.GuildID, Name)
+		if err != nil {
+			return "", err
+		}
+		if c != nil {
+			return ctx.Translate(MessageShortcutCommandConflict, "name", Name), nil
+		}
+	}
+	return "", nil
+}
+
+// ShortcutsConfig is used to configure the guild shortcuts, as well as the built-in commands which are used to manage them.
+type ShortcutsConfig struct {
+	// StorageAdapter is used to store the shortcuts. nil will default to a InMemoryShortcutStorageAdapter.
+	StorageAdapter ShortcutStorageAdapter
+
+	// Category is the category of the shortcut management commands. This can be nil.
+	Category CategoryInterface
+
+	// PermissionValidators is used to limit who can add and remove shortcuts. nil will default to users with the Manage Guild permission.
+	PermissionValidators []PermissionValidator
+
+	// DisableCommands is used to not add the shortcut management commands.
+	DisableCommands bool
+}
+
+// Creates the shortcut management commands.
+func shortcutCommands(Config *ShortcutsConfig) *CommandGroup {
+	validators := Config.PermissionValidators
+	if validators == nil {
+		validators = []PermissionValidator{MANAGE_GUILD(CheckMembersUserPermissions)}
+	}
+	g := &CommandGroup{
+		Name:        "shortcut",
+		Aliases:     []string{"shortcuts"},
+		Description: "Manages the shortcuts for commands in this guild.",
+		Category:    Config.Category,
+	}
+	g.AddCommand(&Command{
+		Name:                 "add",
+		Description:          "Adds a shortcut which runs a command with the arguments given. Use {args} to choose where the arguments given to the shortcut go, otherwise they are added to the end.",
+		Usage:                "<name> <command> [arguments...]",
+		PermissionValidators: validators,
+		ArgTransformers: []ArgTransformer{
+			{Function: StringTransformer},
+			{Function: StringTransformer, Remainder: true},
+		},
+		Function: func(ctx *Context) error {
+			name := strings.ToLower(ctx.Args[0].(string))
+			msg, err := checkShortcutName(ctx, name)
+			if err != nil {
+				return err
+			}
+			if msg != "" {
+				_, err = ctx.Reply(msg)
+				return err
+			}
+			words := strings.Fields(ctx.Args[1].(string))
+			cmd, path, used := findCommand(ctx, words)
+			if cmd == nil {
+				_, err := ctx.Reply(ctx.Translate(MessageHelpNotFound, "command", strings.ToLower(words[0])))
+				return err
+			}
+			err = ctx.Router.ShortcutStorageAdapter.Set(ctx.Message.GuildID, &Shortcut{
+				Name:    name,
+				Command: path,
+				Args:    strings.Join(words[used:], " "),
+			})
+			if err != nil {
+				return err
+			}
+			_, err = ctx.Reply(ctx.Translate(MessageShortcutAdded, "name", name))
+			return err
+		},
+	})
+	g.AddCommand(&Command{
+		Name:                 "remove",
+		Aliases:              []string{"delete"},
+		Description:          "Removes a shortcut.",
+		Usage:                "<name>",
+		PermissionValidators: validators,
+		ArgTransformers: []ArgTransformer{
+			{Function: StringTransformer},
+		},
+		Function: func(ctx *Context) error {
+			name := strings.ToLower(ctx.Args[0].(string))
+			shortcut, err := ctx.Router.ShortcutStorageAdapter.Get(ctx.Message.GuildID, name)
+			if err != nil {
+				return err
+			}
+			if shortcut == nil {
+				_, err = ctx.Reply(ctx.Translate(MessageShortcutNotFound, "name", name))
+				return err
+			}
+			if err = ctx.Router.ShortcutStorageAdapter.Delete(ctx.Message.GuildID, name); err != nil {
+				return err
+			}
+			_, err = ctx.Reply(ctx.Translate(MessageShortcutRemoved, "name", name))
+			return err
+		},
+	})
+	g.AddCommand(&Command{
+		Name:        "list",
+		Description: "Lists the shortcuts in this guild.",
+		Function: func(ctx *Context) error {
+			shortcuts, err := ctx.Router.ShortcutStorageAdapter.List(ctx.Message.GuildID)
+			if err != nil {
+				return err
+			}
+			if len(shortcuts) == 0 {
+				_, err = ctx.Reply(ctx.Translate(MessageShortcutNone))
+				return err
+			}
+			lines := make([]string, len(shortcuts))
+			for i, v := range shortcuts {
+				lines[i] = "`" + ctx.Prefix + v.Name + "` - " + ctx.Translate(MessageShortcutDescription, "command", strings.Trim(v.Command+" "+v.Args, " "))
+			}
+			_, err = ctx.Reply(strings.Join(lines, "\n"))
+			return err
+		},
+	})
+	return g
+}
@@ -0,0 +1,78 @@
+package gommand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auttaja/gommand"
+)
+
+// TestShortcuts is used to test guild admins adding shortcuts for commands.
+func TestShortcuts(t *testing.T) {
+	r, h := newTestHarness(&gommand.RouterConfig{
+		Shortcuts: &gommand.ShortcutsConfig{},
+	})
+	r.SetCommand(&gommand.Command{
+		Name:          "mute",
+		Localisations: map[string]*gommand.CommandLocalisation{"fr": {Name: "muet"}},
+		ArgTransformers: []gommand.ArgTransformer{
+			{Function: gommand.StringTransformer},
+			{Function: gommand.DurationTransformer},
+		},
+		Function: func(ctx *gommand.Context) error {
+			msg := "Muted " + ctx.Args[0].(string) + " for " + ctx.Args[1].(time.Duration).String() + "."
+			if ctx.Shortcut != nil {
+				msg += " (" + ctx.Shortcut.Name + ")"
+			}
+			_, err := ctx.Reply(msg)
+			return err
+		},
+	})
+	config := &gommand.CommandGroup{Name: "config"}
+	config.AddCommand(&gommand.Command{
+		Name:            "prefix",
+		ArgTransformers: []gommand.ArgTransformer{{Function: gommand.StringTransformer}},
+		Function: func(ctx *gommand.Context) error {
+			_, err := ctx.Reply("The prefix is now " + ctx.Args[0].(string) + ".")
+			return err
+		},
+	})
+	r.SetCommand(config)
+	admin := h.Conversation(t, 31, 2)
+	user := h.Conversation(t, 30, 4)
+
+	// Only admins can add shortcuts, and they can't replace commands.
+	user.Send("%shortcut add mute10 mute {args} 10m")
+	user.Expect("You must have the \"Manage Guild\" permission to run this command.")
+	admin.Send("%shortcut add mute mute {args} 10m")
+	admin.Expect("There is already a command called \"mute\".")
+	admin.Send("%shortcut add muet mute {args} 10m")
+	admin.Expect("There is already a command called \"muet\".")
+	admin.Send("%shortcut add x missing")
+	admin.Expect("The command \"missing\" was not found.")
+	admin.Send("%shortcut add Mute10 mute {args} 10m")
+	admin.Expect("Added the shortcut \"mute10\".")
+	admin.Send("%shortcut add prefix config prefix")
+	admin.Expect("Added the shortcut \"prefix\".")
+	admin.Send("%shortcuts list")
+	admin.Expect("`%mute10` - Runs `mute {args} 10m`.\n`%prefix` - Runs `config prefix`.")
+
+	// Use the shortcuts.
+	user.Send("%mute10 bob")
+	user.Expect("Muted bob for 10m0s. (mute10)")
+	user.Send("%prefix !")
+	user.Expect("The prefix is now !.")
+
+	// A command which is set after the shortcut is added is used over the shortcut.
+	r.SetCommand(replyCommand("prefix", "The prefix is %."))
+	user.Send("%prefix !")
+	user.Expect("The prefix is %.")
+
+	// Remove a shortcut.
+	admin.Send("%shortcut remove mute10")
+	admin.Expect("Removed the shortcut \"mute10\".")
+	admin.Send("%shortcut remove mute10")
+	admin.Expect("The shortcut \"mute10\" does not exist.")
+	user.Send("%mute10 bob")
+	user.Expect("The command \"mute10\" does not exist.")
+}
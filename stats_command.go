@@ -0,0 +1,114 @@
+package gommand
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// StatsConfig is used to configure the built-in stats command, which shows the usage statistics of the guild from the routers UsageStore.
+type StatsConfig struct {
+	// Category is the category which the stats command is in. This can be nil.
+	Category CategoryInterface
+
+	// PermissionValidators is used to limit who can use the stats command. This can be nil.
+	PermissionValidators []PermissionValidator
+
+	// Period is how far back the statistics go. 0 will default to a week.
+	Period time.Duration
+
+	// PageSize is the number of commands or users shown on each page. 0 will default to 10.
+	PageSize int
+}
+
+// Creates the pages for the usage counts.
+func statsPages(ctx *Context, Title string, Counts []*UsageCount, PageSize int, entry func(Count *UsageCount) string) []*disgord.Embed {
+	pages := make([]*disgord.Embed, 0, (len(Counts)+PageSize-1)/PageSize)
+	for i := 0; i < len(Counts); i += PageSize {
+		end := i + PageSize
+		if end > len(Counts) {
+			end = len(Counts)
+		}
+		lines := make([]string, 0, end-i)
+		for j, v := range Counts[i:end] {
+			lines = append(lines, "**"+strconv.Itoa(i+j+1)+".** "+entry(v)+" - "+ctx.Translate(MessageStatsEntry, "uses", strconv.Itoa(v.Uses), "errors", strconv.Itoa(v.Errors)))
+		}
+		pages = append(pages, &disgord.Embed{
+			Title:       Title,
+			Description: strings.Join(lines, "\n"),
+			Color:       2818303,
+		})
+	}
+	return pages
+}
+
+// Creates the stats command.
+func statsCommand(Config *StatsConfig) *Command {
+	period := Config.Period
+	if period == 0 {
+		period = time.Hour * 24 * 7
+	}
+	pageSize := Config.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+	return &Command{
+		Name:                 "stats",
+		Description:          "Shows the most used commands in this guild, or the users who use a command the most.",
+		Usage:                "[command] [sub-command...]",
+		Category:             Config.Category,
+		PermissionValidators: Config.PermissionValidators,
+		ArgTransformers: []ArgTransformer{
+			{
+				Optional:  true,
+				Remainder: true,
+				Function:  StringTransformer,
+			},
+		},
+		Function: func(ctx *Context) error {
+			store := ctx.Router.UsageStore
+			if store == nil {
+				return errors.New("the router does not have a usage store")
+			}
+			formattedPeriod := ctx.FormatDuration(period, 0)
+			query := &UsageQuery{GuildID: ctx.Message.GuildID, Since: ctx.Router.clock().Now().Add(-period)}
+			var pages []*disgord.Embed
+			if path, ok := ctx.Args[0].(string); ok {
+				// Get the users who use the command the most.
+				names := strings.Fields(path)
+				cmd, cmdPath, used := findCommand(ctx, names)
+				if cmd == nil || used != len(names) {
+					_, err := ctx.Reply(ctx.Translate(MessageHelpNotFound, "command", strings.ToLower(path)))
+					return err
+				}
+				query.Command = cmdPath
+				counts, err := TopUsers(store, query, 0)
+				if err != nil {
+					return err
+				}
+				title := ctx.Translate(MessageStatsCommandTitle, "command", query.Command, "period", formattedPeriod)
+				pages = statsPages(ctx, title, counts, pageSize, func(Count *UsageCount) string {
+					return "<@" + Count.Key + ">"
+				})
+			} else {
+				// Get the most used commands.
+				counts, err := TopCommands(store, query, 0)
+				if err != nil {
+					return err
+				}
+				title := ctx.Translate(MessageStatsTitle, "period", formattedPeriod)
+				pages = statsPages(ctx, title, counts, pageSize, func(Count *UsageCount) string {
+					return "`" + Count.Key + "`"
+				})
+			}
+			if len(pages) == 0 {
+				_, err := ctx.Reply(ctx.Translate(MessageStatsNoUsage, "period", formattedPeriod))
+				return err
+			}
+			return EmbedsPaginatorWithLifetime(ctx, pages, 0, "", &EmbedLifetimeOptions{InactiveLifetime: time.Minute * 5})
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package gommand_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/auttaja/gommand"
+	"github.com/auttaja/gommand/gommandtest"
+)
+
+// TestStatsCommand is used to test recording the usage of commands and the built-in stats command.
+func TestStatsCommand(t *testing.T) {
+	clock := gommandtest.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := &gommand.InMemoryUsageStore{}
+	r, h := newTestHarness(&gommand.RouterConfig{
+		Clock:      clock,
+		UsageStore: store,
+		Stats:      &gommand.StatsConfig{},
+	})
+	r.SetCommand(replyCommand("ping", "Pong!"))
+	r.SetCommand(&gommand.Command{
+		Name: "fail",
+		Function: func(ctx *gommand.Context) error {
+			return errors.New("failed")
+		},
+	})
+	c := h.Conversation(t, 30, 4)
+	c.Send("%ping")
+	c.Expect("Pong!")
+	c.Send("%ping")
+	c.Expect("Pong!")
+	c.Send("%fail")
+	c.Expect("failed")
+
+	// Check the most used commands and the users of a command.
+	c.Send("%stats")
+	c.Expect("**1.** `ping` - 2 use(s), 0 error(s)\n**2.** `fail` - 1 use(s), 1 error(s)")
+	c.Send("%stats PING")
+	c.Expect("**1.** <@4> - 2 use(s), 0 error(s)")
+	c.Send("%stats missing")
+	c.Expect("The command \"missing\" was not found.")
+	top, err := gommand.TopCommands(store, &gommand.UsageQuery{GuildID: 10, Command: "fail"}, 0)
+	if err != nil || len(top) != 1 || top[0].Uses != 1 || top[0].Errors != 1 {
+		t.Fatal("unexpected top commands:", top, err)
+	}
+
+	// Usage older than the period should be ignored.
+	clock.Advance(time.Hour * 24 * 8)
+	c.Send("%stats")
+	c.Expect("There has been no usage in the last 1 week.")
+}
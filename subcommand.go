@@ -1,11 +1,14 @@
 package gommand
 
 import (
+	"io"
+	"io/ioutil"
 	"sort"
 	"strings"
 )
 
 // CommandGroup is used to have a group of commands which will be executed as sub-commands.
+// Sub-commands inherit the category and cooldown of the group unless they set their own. The permission validators of the group always run before the ones of the sub-command.
 type CommandGroup struct {
 	// Name is used to define the category name.
 	Name string `json:"name"`
@@ -16,23 +19,41 @@ type CommandGroup struct {
 	// Description is used to define a group description.
 	Description string `json:"description"`
 
-	// Category is used to define a group category.
+	// Category is used to define a group category. Sub-commands without a category will inherit this.
 	Category CategoryInterface `json:"category"`
 
-	// Cooldown is used to define a group cooldown.
+	// Cooldown is used to define a group cooldown. Sub-commands without a cooldown will inherit this.
 	Cooldown Cooldown `json:"cooldown"`
 
-	// PermissionValidators defines the permission validators for this group.
+	// PermissionValidators defines the permission validators for this group. These run before the permission validators of any sub-command.
 	PermissionValidators []PermissionValidator `json:"-"`
 
 	// Middleware is used to define the sub-command middleware. Note that this applies to all items in the group.
 	Middleware []Middleware `json:"-"`
 
+	// AroundMiddleware is used to wrap the group, meaning it wraps all items in the group.
+	AroundMiddleware []AroundMiddleware `json:"-"`
+
+	// AfterHooks are called after any command in the group has ran.
+	AfterHooks []AfterHook `json:"-"`
+
+	// Hidden is used to hide the group from the help command for everyone but the bot owners.
+	Hidden bool `json:"hidden"`
+
+	// Localisations is used to define the name, aliases and description of the group in other locales.
+	Localisations map[string]*CommandLocalisation `json:"localisations"`
+
 	// NoCommandSpecified is the command to call when no command is specified.
 	NoCommandSpecified CommandInterface
 
 	// Defines the sub-commands.
 	subcommands map[string]CommandInterface
+
+	// Defines the localised names of the sub-commands.
+	localisedSubcommands map[string]map[string]CommandInterface
+
+	// Defines the sub-commands which were not added since a name or alias was used by a different sub-command.
+	conflicts []*CommandConflict
 }
 
 // GetName is used to get the name.
@@ -76,6 +97,22 @@ func (g *CommandGroup) GetUsage() string {
 	return x + ">"
 }
 
+// GetLocalisedUsage is used to get the usage with the names and aliases of the sub-commands in the locale.
+func (g *CommandGroup) GetLocalisedUsage(Locale string) string {
+	if Locale == "" || len(g.subcommands) == 0 {
+		return g.GetUsage()
+	}
+	keys := make([]string, 0, len(g.subcommands))
+	for _, v := range g.GetSubcommands() {
+		keys = append(keys, strings.ToLower(LocalisedName(v, Locale)))
+		for _, alias := range LocalisedAliases(v, Locale) {
+			keys = append(keys, strings.ToLower(alias))
+		}
+	}
+	sort.Strings(keys)
+	return "<" + strings.Join(keys, "/") + ">"
+}
+
 // GetCategory is used to get the category.
 func (g *CommandGroup) GetCategory() CategoryInterface {
 	return g.Category
@@ -111,47 +148,129 @@ func (g *CommandGroup) GetMiddleware() []Middleware {
 	return g.Middleware
 }
 
+// GetAroundMiddleware is used to get the around middleware.
+func (g *CommandGroup) GetAroundMiddleware() []AroundMiddleware {
+	return g.AroundMiddleware
+}
+
+// GetAfterHooks is used to get the after hooks.
+func (g *CommandGroup) GetAfterHooks() []AfterHook {
+	return g.AfterHooks
+}
+
+// GetHidden is used to get if the group is hidden.
+func (g *CommandGroup) GetHidden() bool {
+	return g.Hidden
+}
+
+// GetLocalisations is used to get the names, aliases and descriptions of the group in other locales.
+func (g *CommandGroup) GetLocalisations() map[string]*CommandLocalisation {
+	return g.Localisations
+}
+
 // CommandFunction is the command function which will be called.
 func (g *CommandGroup) CommandFunction(ctx *Context) error {
 	cmdname, ok := ctx.Args[0].(string)
 	if !ok {
 		// Handle the no command specified event if it is set.
 		if g.NoCommandSpecified != nil {
+			ctx.Router.hooks().CommandResolved(ctx, g.NoCommandSpecified)
 			return runCommand(ctx, strings.NewReader(""), g.NoCommandSpecified)
 		}
 
 		// Send an error to the router.
-		return &CommandBlank{err: "This group expects a command but none was given."}
+		return &CommandBlank{err: ctx.Translate(MessageGroupCommandBlank)}
 	}
 	args, _ := ctx.Args[1].(string)
-	subcommand, ok := g.subcommands[strings.ToLower(cmdname)]
-	if ok {
+	subcommand := g.GetLocalisedCommand(ctx.Locale(), cmdname)
+	if subcommand != nil {
 		// Return this command handler.
+		ctx.Router.hooks().CommandResolved(ctx, subcommand)
 		return runCommand(ctx, strings.NewReader(args), subcommand)
 	}
-	return &CommandNotFound{err: "The command specified for the group was not found."}
+	return &CommandNotFound{err: ctx.Translate(MessageGroupCommandNotFound)}
 }
 
 // Init is used to initialise the commands group.
 func (g *CommandGroup) Init() {
+	cmds := g.GetSubcommands()
 	if g.NoCommandSpecified != nil {
-		g.NoCommandSpecified.Init()
+		cmds = append(cmds, g.NoCommandSpecified)
 	}
-	for _, v := range g.GetSubcommands() {
+	for _, v := range cmds {
 		v.Init()
 	}
 }
 
+// Initialises the cooldowns of the sub-commands which are not shared with the group.
+func (g *CommandGroup) initCooldowns() {
+	cmds := g.GetSubcommands()
+	if g.NoCommandSpecified != nil {
+		cmds = append(cmds, g.NoCommandSpecified)
+	}
+	for _, v := range cmds {
+		if cooldown := v.GetCooldown(); cooldown != nil && cooldown != g.Cooldown {
+			cooldown.Init()
+		}
+		if sub, ok := v.(*CommandGroup); ok {
+			sub.initCooldowns()
+		}
+	}
+}
+
+// Checks if the names of the command are used by a different sub-command.
+func (g *CommandGroup) conflict(cmd CommandInterface) *CommandConflict {
+	keys, localised := commandKeys(cmd)
+	for _, k := range keys {
+		if existing := g.subcommands[k]; existing != nil && existing != cmd {
+			return &CommandConflict{Name: k, Command: cmd, Existing: existing}
+		}
+	}
+	for locale, names := range localised {
+		for _, k := range names {
+			if existing := g.localisedSubcommands[locale][k]; existing != nil && existing != cmd {
+				return &CommandConflict{Name: k, Locale: locale, Command: cmd, Existing: existing}
+			}
+		}
+	}
+	return nil
+}
+
 // AddCommand is used to add a command to the group.
+// If a name or alias of the command is used by a different sub-command, the command is not added, and the conflict is returned when the group is set in the router or validated.
 func (g *CommandGroup) AddCommand(cmd CommandInterface) {
 	if g.subcommands == nil {
 		g.subcommands = map[string]CommandInterface{}
 	}
+	cmd.Init()
+	if err := g.conflict(cmd); err != nil {
+		g.conflicts = append(g.conflicts, err)
+		return
+	}
 	cmdname := strings.ToLower(cmd.GetName())
 	g.subcommands[cmdname] = cmd
 	for _, alias := range cmd.GetAliases() {
 		g.subcommands[strings.ToLower(alias)] = cmd
 	}
+	if g.localisedSubcommands == nil {
+		g.localisedSubcommands = map[string]map[string]CommandInterface{}
+	}
+	addLocalisedCommand(g.localisedSubcommands, cmd)
+}
+
+// GetCommand is used to get a sub-command from the group if it exists.
+// If the command doesn't exist, this will be nil.
+func (g *CommandGroup) GetCommand(Name string) CommandInterface {
+	return g.subcommands[strings.ToLower(Name)]
+}
+
+// GetLocalisedCommand is used to get a sub-command from the group by its name in the locale, falling back to the name of the command.
+// If the command doesn't exist, this will be nil.
+func (g *CommandGroup) GetLocalisedCommand(Locale, Name string) CommandInterface {
+	if cmd := getLocalisedCommand(g.localisedSubcommands, Locale, Name); cmd != nil {
+		return cmd
+	}
+	return g.GetCommand(Name)
 }
 
 // GetSubcommands is used to get all the sub-commands of this group.
@@ -174,3 +293,101 @@ func (g *CommandGroup) GetSubcommands() []CommandInterface {
 	}
 	return uniques
 }
+
+// Used to make a sub-command inherit the category and cooldown of its group unless it sets its own, and run the permission validators of its group before its own.
+type inheritedCommand struct {
+	CommandInterface
+	parent CommandInterface
+}
+
+// GetCategory is used to get the category.
+func (c *inheritedCommand) GetCategory() CategoryInterface {
+	if cat := c.CommandInterface.GetCategory(); cat != nil {
+		return cat
+	}
+	return c.parent.GetCategory()
+}
+
+// GetCooldown is used to get the cooldown.
+func (c *inheritedCommand) GetCooldown() Cooldown {
+	if cooldown := c.CommandInterface.GetCooldown(); cooldown != nil {
+		return cooldown
+	}
+	return c.parent.GetCooldown()
+}
+
+// GetPermissionValidators is used to get the permission validators. The validators of the group run before the ones of the sub-command.
+func (c *inheritedCommand) GetPermissionValidators() []PermissionValidator {
+	parent := c.parent.GetPermissionValidators()
+	validators := make([]PermissionValidator, 0, len(parent)+len(c.CommandInterface.GetPermissionValidators()))
+	validators = append(validators, parent...)
+	return append(validators, c.CommandInterface.GetPermissionValidators()...)
+}
+
+// Resolves any sub-commands which are being invoked, adding their groups to the context.
+// This returns the command to run, the command with the attributes it inherits from its groups, and the reader for its arguments.
+func resolveSubcommands(ctx *Context, reader io.ReadSeeker, c CommandInterface) (CommandInterface, CommandInterface, io.ReadSeeker) {
+	ctx.Command = c
+	g, ok := c.(*CommandGroup)
+	if !ok {
+		return c, c, reader
+	}
+	b, _ := ioutil.ReadAll(reader)
+	args := string(b)
+	inherited := c
+	for ok {
+		// Get the sub-command.
+		parser := ctx.Router.parserManager.Parser(strings.NewReader(args))
+		arg := parser.GetNextArg()
+		var subcommand CommandInterface
+		remainder := ""
+		if arg == nil {
+			subcommand = g.NoCommandSpecified
+		} else {
+			subcommand = g.GetLocalisedCommand(ctx.Locale(), arg.Text)
+			remainder, _ = parser.Remainder()
+		}
+		parser.Done()
+		if subcommand == nil {
+			// The group will handle this itself.
+			break
+		}
+
+		// Add the group to the path.
+		ctx.Groups = append(ctx.Groups, g)
+		ctx.Command = subcommand
+		ctx.Router.hooks().CommandResolved(ctx, subcommand)
+		c = subcommand
+		inherited = &inheritedCommand{CommandInterface: subcommand, parent: inherited}
+		args = strings.Trim(remainder, " ")
+		g, ok = subcommand.(*CommandGroup)
+	}
+	return c, inherited, strings.NewReader(args)
+}
+
+// Finds the command with the names (which can be in the locale of the invocation), going into command groups for as many of the names as possible.
+// This returns the command, the path of the command using the names of the commands, and the number of names which were used. If the first name is not a command, the command is nil.
+func findCommand(ctx *Context, Names []string) (CommandInterface, string, int) {
+	if len(Names) == 0 {
+		return nil, "", 0
+	}
+	locale := ctx.Locale()
+	cmd := ctx.Router.GetLocalisedCommand(locale, Names[0])
+	if cmd == nil {
+		return nil, "", 0
+	}
+	path := []string{cmd.GetName()}
+	for _, name := range Names[1:] {
+		g, ok := cmd.(*CommandGroup)
+		if !ok {
+			break
+		}
+		subcommand := g.GetLocalisedCommand(locale, name)
+		if subcommand == nil {
+			break
+		}
+		cmd = subcommand
+		path = append(path, cmd.GetName())
+	}
+	return cmd, strings.Join(path, " "), len(path)
+}
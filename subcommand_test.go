@@ -1,6 +1,10 @@
 package gommand
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+)
 
 // TestSubcommand is used to test that subcommands work properly.
 func TestSubcommand(t *testing.T) {
@@ -52,3 +56,85 @@ func TestSubcommand(t *testing.T) {
 	failed = false
 	r.CommandProcessor(nil, 0, mockMessage("%b arg_expected test"), true)
 }
+
+// TestSubcommandInheritance is used to test that sub-commands inherit from their groups and that router stages only run once.
+func TestSubcommandInheritance(t *testing.T) {
+	events := make([]string, 0)
+	validator := func(name string, ok bool) PermissionValidator {
+		return func(ctx *Context) (string, bool) {
+			events = append(events, name)
+			return name + " denied", ok
+		}
+	}
+	cat := &Category{Name: "cat", PermissionValidators: []PermissionValidator{validator("category", true)}}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck:          StaticPrefix("%"),
+		PermissionValidators: []PermissionValidator{validator("router", true)},
+		Middleware: []Middleware{func(ctx *Context) error {
+			events = append(events, "middleware")
+			return nil
+		}},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	var ctx *Context
+	run := func(ctx2 *Context) error {
+		ctx = ctx2
+		events = append(events, "function")
+		return nil
+	}
+	inner := &CommandGroup{Name: "inner"}
+	inner.AddCommand(&Command{Name: "leaf", Function: run})
+	inner.AddCommand(&Command{
+		Name:                 "override",
+		PermissionValidators: []PermissionValidator{validator("override", false)},
+		Function:             run,
+	})
+	outer := &CommandGroup{
+		Name:                 "outer",
+		Category:             cat,
+		PermissionValidators: []PermissionValidator{validator("group", true)},
+		Cooldown:             &UserCooldown{MaxRuns: 1, UsageExpires: time.Minute},
+	}
+	outer.AddCommand(inner)
+	r.SetCommand(outer)
+
+	// The leaf should inherit everything from the outer group.
+	r.CommandProcessor(nil, 0, mockMessage("%outer inner leaf"), true)
+	if lastErr != nil {
+		t.Fatal(lastErr)
+	}
+	expected := "router, category, group, middleware, function"
+	if strings.Join(events, ", ") != expected {
+		t.Fatalf("expected %s, got %s", expected, strings.Join(events, ", "))
+	}
+	if ctx.CommandPath() != "outer inner leaf" || len(ctx.Groups) != 2 || ctx.Groups[0] != outer || ctx.Groups[1] != inner {
+		t.Fatal("unexpected command path:", ctx.CommandPath())
+	}
+	if ctx.Command.GetName() != "leaf" || ctx.Command.GetCategory() != nil {
+		t.Fatal("the context should have the command which ran")
+	}
+	if ctx.Category() != cat {
+		t.Fatal("the context should have the inherited category")
+	}
+
+	// The cooldown is inherited.
+	r.CommandProcessor(nil, 0, mockMessage("%outer inner leaf"), true)
+	if _, ok := lastErr.(*CommandOnCooldown); !ok {
+		t.Fatal("expected the inherited cooldown to be hit, got", lastErr)
+	}
+
+	// Validators which are set run after the group.
+	events = events[:0]
+	r.CommandProcessor(nil, 0, mockMessage("%outer inner override"), true)
+	if e, ok := lastErr.(*IncorrectPermissions); !ok || e.Error() != "override denied" {
+		t.Fatal("expected the sub-command validator to fail, got", lastErr)
+	}
+	expected = "router, category, group, override"
+	if strings.Join(events, ", ") != expected {
+		t.Fatalf("expected %s, got %s", expected, strings.Join(events, ", "))
+	}
+}
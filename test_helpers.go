@@ -1,6 +1,10 @@
 package gommand
 
-import "github.com/andersfylling/disgord"
+import (
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
 
 func mockMessage(content string) *disgord.Message {
 	return &disgord.Message{
@@ -15,3 +19,27 @@ func mockMessage(content string) *disgord.Message {
 		Application:     disgord.MessageApplication{},
 	}
 }
+
+// A clock which only moves when it is told to. Functions scheduled on it are never called.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) AfterFunc(d time.Duration, f func()) Timer {
+	return testTimer{}
+}
+
+// The timer returned by the test clock.
+type testTimer struct{}
+
+func (testTimer) Stop() bool {
+	return false
+}
+
+func (testTimer) Reset(d time.Duration) bool {
+	return false
+}
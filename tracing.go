@@ -0,0 +1,165 @@
+package gommand
+
+import (
+	"sync"
+	"time"
+)
+
+// Span is used to define the interface for a span within a trace.
+type Span interface {
+	// SetAttribute is used to set an attribute on the span.
+	SetAttribute(Key string, Value interface{})
+
+	// RecordError is used to record an error on the span.
+	RecordError(err error)
+
+	// End is used to mark the span as finished.
+	End()
+}
+
+// Tracer is used to define the interface which is used to create spans while processing a message.
+// This allows you to plug in your own tracing backend.
+type Tracer interface {
+	// StartSpan is used to start a span with the name specified. If the parent is nil, this is the root span of a new trace.
+	StartSpan(Parent Span, Name string) Span
+}
+
+// A span which does nothing.
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(string, interface{}) {}
+func (nopSpan) RecordError(error)                {}
+func (nopSpan) End()                             {}
+
+// A tracer which does nothing.
+type nopTracer struct{}
+
+func (nopTracer) StartSpan(Span, string) Span {
+	return nopSpan{}
+}
+
+// Gets the tracer which the router uses. This is safe to call on a nil router.
+func (r *Router) tracer() Tracer {
+	if r == nil || r.Tracer == nil {
+		return nopTracer{}
+	}
+	return r.Tracer
+}
+
+// StartSpan is used to start a span within the current span of the context.
+// Within a command, this is a child of the span for the command function.
+func (c *Context) StartSpan(Name string) Span {
+	return c.Router.tracer().StartSpan(c.span, Name)
+}
+
+// Runs the function within a span, recording the error if there is one.
+func (c *Context) traceFunc(Name string, f func(span Span) error) error {
+	span := c.StartSpan(Name)
+	defer span.End()
+	err := f(span)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// RecordedSpan is a span which has been recorded by the InMemoryTracer.
+type RecordedSpan struct {
+	lock *sync.Mutex
+
+	// Name is the name of the span.
+	Name string
+
+	// Parent is the parent of the span. This is nil for the root span.
+	Parent *RecordedSpan
+
+	// Attributes are the attributes which were set on the span.
+	Attributes map[string]interface{}
+
+	// Errors are the errors which were recorded on the span.
+	Errors []error
+
+	// Start is when the span was started.
+	Start time.Time
+
+	// Finish is when the span ended. This is zero if it hasn't ended.
+	Finish time.Time
+}
+
+// SetAttribute is used to set an attribute on the span.
+func (s *RecordedSpan) SetAttribute(Key string, Value interface{}) {
+	s.lock.Lock()
+	s.Attributes[Key] = Value
+	s.lock.Unlock()
+}
+
+// RecordError is used to record an error on the span.
+func (s *RecordedSpan) RecordError(err error) {
+	s.lock.Lock()
+	s.Errors = append(s.Errors, err)
+	s.lock.Unlock()
+}
+
+// End is used to mark the span as finished.
+func (s *RecordedSpan) End() {
+	s.lock.Lock()
+	s.Finish = time.Now()
+	s.lock.Unlock()
+}
+
+// Root is used to get the root span of the trace this span is within.
+func (s *RecordedSpan) Root() *RecordedSpan {
+	for s.Parent != nil {
+		s = s.Parent
+	}
+	return s
+}
+
+// InMemoryTracer implements the Tracer interface and records all spans in memory. This is useful for tests.
+type InMemoryTracer struct {
+	lock  sync.Mutex
+	spans []*RecordedSpan
+}
+
+// StartSpan is used to start recording a span.
+func (t *InMemoryTracer) StartSpan(Parent Span, Name string) Span {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	parent, _ := Parent.(*RecordedSpan)
+	span := &RecordedSpan{
+		lock:       &t.lock,
+		Name:       Name,
+		Parent:     parent,
+		Attributes: map[string]interface{}{},
+		Start:      time.Now(),
+	}
+	t.spans = append(t.spans, span)
+	return span
+}
+
+// Spans is used to get all of the spans which have been started, in the order they were started.
+func (t *InMemoryTracer) Spans() []*RecordedSpan {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]*RecordedSpan{}, t.spans...)
+}
+
+// Children is used to get the spans which are direct children of the span specified, in the order they were started.
+func (t *InMemoryTracer) Children(Parent *RecordedSpan) []*RecordedSpan {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	a := make([]*RecordedSpan, 0)
+	for _, v := range t.spans {
+		if v.Parent == Parent {
+			a = append(a, v)
+		}
+	}
+	return a
+}
+
+// Reset is used to remove all of the recorded spans.
+func (t *InMemoryTracer) Reset() {
+	t.lock.Lock()
+	t.spans = nil
+	t.lock.Unlock()
+}
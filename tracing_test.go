@@ -0,0 +1,89 @@
+package gommand
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTracing is used to test the spans created while processing a message.
+func TestTracing(t *testing.T) {
+	tracer := &InMemoryTracer{}
+	r := NewRouter(&RouterConfig{
+		PrefixCheck: StaticPrefix("%"),
+		Tracer:      tracer,
+		GetState: func(ctx *Context) error {
+			return nil
+		},
+		PermissionValidators: []PermissionValidator{func(ctx *Context) (string, bool) {
+			return "", true
+		}},
+		Middleware: []Middleware{func(ctx *Context) error {
+			return nil
+		}},
+	})
+	var lastErr error
+	r.AddErrorHandler(func(_ *Context, err error) bool {
+		lastErr = err
+		return true
+	})
+	r.SetCommand(&Command{
+		Name:     "trace",
+		Cooldown: &UserCooldown{MaxRuns: 10, UsageExpires: time.Hour},
+		Middleware: []Middleware{func(ctx *Context) error {
+			return nil
+		}},
+		ArgTransformers: []ArgTransformer{{Function: IntTransformer}, {Function: StringTransformer}},
+		Function: func(ctx *Context) error {
+			ctx.StartSpan("work").End()
+			return nil
+		},
+	})
+
+	// Check the spans for a successful command.
+	msg := mockMessage("%trace 1 a")
+	msg.ChannelID = 2
+	r.CommandProcessor(nil, 3, msg, true)
+	if lastErr != nil {
+		t.Fatal(lastErr)
+	}
+	spans := tracer.Spans()
+	root := spans[0]
+	if root.Name != "message" || root.Parent != nil || root.Finish.IsZero() {
+		t.Fatal("the root span is not correct")
+	}
+	if root.Attributes["guild_id"] != "1" || root.Attributes["channel_id"] != "2" || root.Attributes["shard_id"] != uint(3) || root.Attributes["command"] != "trace" {
+		t.Fatal("the root attributes are not correct:", root.Attributes)
+	}
+	names := make([]string, 0)
+	var cmdSpan *RecordedSpan
+	for _, v := range tracer.Children(root) {
+		names = append(names, v.Name)
+		if v.Name == "command" {
+			cmdSpan = v
+		}
+	}
+	expected := "get_state prefix_check permission_validator cooldown middleware middleware arg_transformer arg_transformer command"
+	if strings.Join(names, " ") != expected {
+		t.Fatalf("expected the spans %q, got %q", expected, strings.Join(names, " "))
+	}
+	if children := tracer.Children(cmdSpan); len(children) != 1 || children[0].Name != "work" {
+		t.Fatal("the span started in the command should be a child of the command span")
+	}
+
+	// Check errors from transformers are recorded.
+	tracer.Reset()
+	r.CommandProcessor(nil, 0, mockMessage("%trace a"), true)
+	if lastErr == nil {
+		t.Fatal("expected the transformer to error")
+	}
+	for _, v := range tracer.Spans() {
+		if v.Name == "arg_transformer" {
+			if len(v.Errors) != 1 || v.Errors[0] != lastErr {
+				t.Fatal("the transformer error was not recorded")
+			}
+			return
+		}
+	}
+	t.Fatal("no transformer span was recorded")
+}
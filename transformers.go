@@ -15,19 +15,19 @@ func StringTransformer(_ *Context, Arg string) (interface{}, error) {
 }
 
 // IntTransformer is used to transform an arg to a integer if possible.
-func IntTransformer(_ *Context, Arg string) (interface{}, error) {
+func IntTransformer(ctx *Context, Arg string) (interface{}, error) {
 	i, err := strconv.Atoi(Arg)
 	if err != nil {
-		return nil, &InvalidTransformation{Description: "Could not transform the argument to an integer."}
+		return nil, &InvalidTransformation{Description: ctx.Translate(MessageInvalidInteger)}
 	}
 	return i, nil
 }
 
 // UIntTransformer is used to transform an arg to a unsigned integer if possible.
-func UIntTransformer(_ *Context, Arg string) (interface{}, error) {
+func UIntTransformer(ctx *Context, Arg string) (interface{}, error) {
 	i, err := strconv.ParseUint(Arg, 10, 64)
 	if err != nil {
-		return nil, &InvalidTransformation{Description: "Could not transform the argument to an unsigned integer."}
+		return nil, &InvalidTransformation{Description: ctx.Translate(MessageInvalidUnsignedInteger)}
 	}
 	return i, nil
 }
@@ -42,7 +42,7 @@ func safeSnowflakeParse(x string) *disgord.Snowflake {
 
 // UserTransformer is used to transform a user if possible.
 func UserTransformer(ctx *Context, Arg string) (user interface{}, err error) {
-	err = &InvalidTransformation{Description: "This was not a valid user ID or mention."}
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidUser)}
 	id := getMention(strings.NewReader(Arg), '@', false)
 	if id == nil {
 		return
@@ -60,7 +60,7 @@ func UserTransformer(ctx *Context, Arg string) (user interface{}, err error) {
 
 // MemberTransformer is used to transform a member if possible.
 func MemberTransformer(ctx *Context, Arg string) (member interface{}, err error) {
-	err = &InvalidTransformation{Description: "This was not a valid user ID or mention of someone in this guild."}
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidMember)}
 	id := getMention(strings.NewReader(Arg), '@', false)
 	if id == nil {
 		return
@@ -78,7 +78,7 @@ func MemberTransformer(ctx *Context, Arg string) (member interface{}, err error)
 
 // ChannelTransformer is used to transform a channel if possible.
 func ChannelTransformer(ctx *Context, Arg string) (channel interface{}, err error) {
-	err = &InvalidTransformation{Description: "This was not a valid channel ID or mention of a channel in this guild."}
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidChannel)}
 	id := getMention(strings.NewReader(Arg), '#', false)
 	if id == nil {
 		return
@@ -96,7 +96,7 @@ func ChannelTransformer(ctx *Context, Arg string) (channel interface{}, err erro
 
 // GuildTransformer is used to transform a guild if possible.
 func GuildTransformer(ctx *Context, Arg string) (guild interface{}, err error) {
-	err = &InvalidTransformation{Description: "This was not a valid guild ID."}
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidGuild)}
 	x := safeSnowflakeParse(Arg)
 	if x == nil {
 		return
@@ -137,7 +137,7 @@ func getMessageIds(manager *fastparse.ParserManager, start string, iterator io.R
 
 // MessageURLTransformer is used to transform a message URL to a message if possible.
 func MessageURLTransformer(ctx *Context, Arg string) (message interface{}, err error) {
-	err = &InvalidTransformation{Description: "This is not a valid message URL or a message which the bot cannot access."}
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidMessageURL)}
 	discordMsgLinks := []string{
 		"https://discordapp.com/channels/",
 		"https://discord.com/channels/",
@@ -185,17 +185,17 @@ var str2bool = map[string]bool{
 }
 
 // BooleanTransformer is used to transform an argument into a boolean if possible.
-func BooleanTransformer(_ *Context, Arg string) (interface{}, error) {
+func BooleanTransformer(ctx *Context, Arg string) (interface{}, error) {
 	boolean, ok := str2bool[strings.ToLower(Arg)]
 	if !ok {
-		return nil, &InvalidTransformation{Description: "This is not a valid boolean representation."}
+		return nil, &InvalidTransformation{Description: ctx.Translate(MessageInvalidBoolean)}
 	}
 	return boolean, nil
 }
 
 // RoleTransformer is used to transform a role if possible.
 func RoleTransformer(ctx *Context, Arg string) (role interface{}, err error) {
-	err = &InvalidTransformation{Description: "This was not a valid role ID, mention or name of a role in this guild."}
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidRole)}
 	id := getMention(strings.NewReader(Arg), '@', true)
 	roles, e := ctx.Session.Guild(ctx.Message.GuildID).GetRoles()
 	if e != nil {
@@ -223,8 +223,8 @@ func RoleTransformer(ctx *Context, Arg string) (role interface{}, err error) {
 }
 
 // DurationTransformer is used to transform a duration if possible.
-func DurationTransformer(_ *Context, Arg string) (duration interface{}, err error) {
-	err = &InvalidTransformation{Description: "This was not a valid duration."}
+func DurationTransformer(ctx *Context, Arg string) (duration interface{}, err error) {
+	err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidDuration)}
 	duration, e := time.ParseDuration(Arg)
 	if e == nil {
 		err = nil
@@ -235,7 +235,7 @@ func DurationTransformer(_ *Context, Arg string) (duration interface{}, err erro
 // AnyTransformer takes multiple transformers and tries to find one which works.
 func AnyTransformer(Transformers ...func(ctx *Context, Arg string) (interface{}, error)) func(ctx *Context, Arg string) (item interface{}, err error) {
 	return func(ctx *Context, Arg string) (item interface{}, err error) {
-		err = &InvalidTransformation{Description: "Unable to transform the argument properly."}
+		err = &InvalidTransformation{Description: ctx.Translate(MessageInvalidArgument)}
 		for _, v := range Transformers {
 			res, e := v(ctx, Arg)
 			if e == nil {
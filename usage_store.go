@@ -0,0 +1,170 @@
+package gommand
+
+import (
+	"sort"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// UsageRecord is the information which is recorded every time a command is ran.
+type UsageRecord struct {
+	// Command is the path of the command including any command groups (for example, "config prefix").
+	Command string `json:"command"`
+
+	// GuildID is the ID of the guild the command was ran in.
+	GuildID disgord.Snowflake `json:"guildId"`
+
+	// UserID is the ID of the user who ran the command.
+	UserID disgord.Snowflake `json:"userId"`
+
+	// Time is when the command was ran.
+	Time time.Time `json:"time"`
+
+	// Error is true if the command returned an error or panicked.
+	Error bool `json:"error"`
+}
+
+// UsageQuery is used to filter the usage records. Any fields which are not set match every record.
+type UsageQuery struct {
+	// GuildID is the ID of the guild.
+	GuildID disgord.Snowflake
+
+	// UserID is the ID of the user.
+	UserID disgord.Snowflake
+
+	// Command is the path of the command.
+	Command string
+
+	// Since is the earliest time a record can have.
+	Since time.Time
+
+	// Until is the time records must be before.
+	Until time.Time
+}
+
+// Matches is used to check if the record matches the query. A nil query matches every record.
+func (q *UsageQuery) Matches(Record *UsageRecord) bool {
+	if q == nil {
+		return true
+	}
+	if q.GuildID != 0 && Record.GuildID != q.GuildID {
+		return false
+	}
+	if q.UserID != 0 && Record.UserID != q.UserID {
+		return false
+	}
+	if q.Command != "" && Record.Command != q.Command {
+		return false
+	}
+	if !q.Since.IsZero() && Record.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !Record.Time.Before(q.Until) {
+		return false
+	}
+	return true
+}
+
+// UsageStore is the interface which is used to store the usage of commands.
+type UsageStore interface {
+	// Called when the router is created.
+	Init()
+
+	// Record is used to store a usage record.
+	Record(Record *UsageRecord) error
+
+	// Query is used to get the records which match the query.
+	Query(Query *UsageQuery) ([]*UsageRecord, error)
+}
+
+// UsageCount is the number of uses and errors of a command or user.
+type UsageCount struct {
+	// Key is the path of the command or the ID of the user which this is counting.
+	Key string
+
+	// Uses is the number of times the command was ran.
+	Uses int
+
+	// Errors is the number of times the command returned an error.
+	Errors int
+}
+
+// Counts the records which match the query by a key, ordered by the most uses. A Limit of 0 means there is no limit.
+func countUsage(Store UsageStore, Query *UsageQuery, Limit int, key func(Record *UsageRecord) string) ([]*UsageCount, error) {
+	records, err := Store.Query(Query)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]*UsageCount{}
+	for _, v := range records {
+		k := key(v)
+		c := counts[k]
+		if c == nil {
+			c = &UsageCount{Key: k}
+			counts[k] = c
+		}
+		c.Uses++
+		if v.Error {
+			c.Errors++
+		}
+	}
+	a := make([]*UsageCount, 0, len(counts))
+	for _, v := range counts {
+		a = append(a, v)
+	}
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].Uses == a[j].Uses {
+			return a[i].Key < a[j].Key
+		}
+		return a[i].Uses > a[j].Uses
+	})
+	if Limit > 0 && len(a) > Limit {
+		a = a[:Limit]
+	}
+	return a, nil
+}
+
+// TopCommands is used to get the most used commands which match the query. A Limit of 0 means there is no limit.
+func TopCommands(Store UsageStore, Query *UsageQuery, Limit int) ([]*UsageCount, error) {
+	return countUsage(Store, Query, Limit, func(Record *UsageRecord) string {
+		return Record.Command
+	})
+}
+
+// TopUsers is used to get the users who have used the commands which match the query the most. The key of each count is the user ID. A Limit of 0 means there is no limit.
+func TopUsers(Store UsageStore, Query *UsageQuery, Limit int) ([]*UsageCount, error) {
+	return countUsage(Store, Query, Limit, func(Record *UsageRecord) string {
+		return Record.UserID.String()
+	})
+}
+
+// TopCommandsInGuild is used to get the most used commands in a guild over the period up until now, such as the top commands this week.
+// A Limit of 0 means there is no limit. If the router does not have a usage store, this will be nil.
+func (r *Router) TopCommandsInGuild(GuildID disgord.Snowflake, Period time.Duration, Limit int) ([]*UsageCount, error) {
+	if r.UsageStore == nil {
+		return nil, nil
+	}
+	return TopCommands(r.UsageStore, &UsageQuery{GuildID: GuildID, Since: r.clock().Now().Add(-Period)}, Limit)
+}
+
+// Records the usage of a command in the usage store if there is one.
+func (r *Router) recordUsage(ctx *Context, err error) {
+	if r.UsageStore == nil || ctx.Message == nil {
+		return
+	}
+	record := &UsageRecord{
+		Command: ctx.CommandPath(),
+		GuildID: ctx.Message.GuildID,
+		Time:    r.clock().Now(),
+		Error:   err != nil,
+	}
+	if ctx.Message.Author != nil {
+		record.UserID = ctx.Message.Author.ID
+	}
+	if storeErr := r.UsageStore.Record(record); storeErr != nil && r.Logger != nil {
+		fields := ctx.logFields()
+		fields["error"] = storeErr.Error()
+		r.Logger.Error("Failed to record the command usage.", fields)
+	}
+}
@@ -0,0 +1,60 @@
+package gommand
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestUsageStores is used to test recording and querying the usage of commands.
+func TestUsageStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gommand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	now := time.Now()
+	for name, store := range map[string]UsageStore{
+		"memory":     &InMemoryUsageStore{},
+		"json lines": &JSONLinesUsageStore{Path: filepath.Join(dir, "usage.jsonl")},
+	} {
+		r := NewRouter(&RouterConfig{UsageStore: store})
+		if records, err := store.Query(nil); err != nil || len(records) != 0 {
+			t.Fatal(name, "the store should start empty:", records, err)
+		}
+		for _, v := range []*UsageRecord{
+			{Command: "ping", GuildID: 1, UserID: 2, Time: now},
+			{Command: "ping", GuildID: 1, UserID: 3, Time: now.Add(-time.Hour)},
+			{Command: "config prefix", GuildID: 1, UserID: 2, Time: now, Error: true},
+			{Command: "config prefix", GuildID: 2, UserID: 2, Time: now},
+			{Command: "config prefix", GuildID: 2, UserID: 2, Time: now},
+			{Command: "old", GuildID: 1, UserID: 2, Time: now.Add(-time.Hour * 24 * 30)},
+		} {
+			if err := store.Record(v); err != nil {
+				t.Fatal(name, err)
+			}
+		}
+
+		// Check the most used commands in the guild this week.
+		top, err := r.TopCommandsInGuild(1, time.Hour*24*7, 0)
+		if err != nil || len(top) != 2 || top[0].Key != "ping" || top[0].Uses != 2 || top[1].Key != "config prefix" || top[1].Errors != 1 {
+			t.Fatal(name, "unexpected top commands:", top, err)
+		}
+		top, err = TopCommands(store, nil, 1)
+		if err != nil || len(top) != 1 || top[0].Key != "config prefix" || top[0].Uses != 3 {
+			t.Fatal(name, "unexpected top commands:", top, err)
+		}
+
+		// Check the users who used a command the most.
+		top, err = TopUsers(store, &UsageQuery{Command: "ping", Since: now.Add(-time.Minute)}, 0)
+		if err != nil || len(top) != 1 || top[0].Key != "2" {
+			t.Fatal(name, "unexpected top users:", top, err)
+		}
+		records, err := store.Query(&UsageQuery{UserID: 2, Until: now.Add(-time.Minute)})
+		if err != nil || len(records) != 1 || records[0].Command != "old" {
+			t.Fatal(name, "unexpected records:", records, err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package gommand
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CommandValidationError is the error which is returned by Validate when there are problems with the commands.
+type CommandValidationError struct {
+	// Problems is the description of each problem which was found, prefixed with the path of the command.
+	Problems []string
+}
+
+// Error is used to give the error description.
+func (c *CommandValidationError) Error() string {
+	return "the commands are invalid:\n" + strings.Join(c.Problems, "\n")
+}
+
+// Checks if two transformer functions are the same function.
+func sameTransformer(a, b func(ctx *Context, Arg string) (interface{}, error)) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// Checks the order of the argument transformers, returning a description of the problem or a blank string if they are valid.
+func validateArgTransformers(Transformers []ArgTransformer) string {
+	optional := false
+	var greedy *ArgTransformer
+	for i, v := range Transformers {
+		arg := "argument " + strconv.Itoa(i+1)
+		if v.Function == nil {
+			return arg + " has no transformer function"
+		}
+		if v.Remainder && v.Greedy {
+			return arg + " cannot be both greedy and a remainder"
+		}
+		if v.Remainder && i != len(Transformers)-1 {
+			return arg + " is a remainder but is not the last argument"
+		}
+		if v.Optional || v.Default != nil {
+			optional = true
+		} else if optional {
+			return arg + " is required but follows an optional argument"
+		}
+
+		// A greedy argument keeps going until the transformer fails, so the argument after it cannot be reached if it would always be consumed.
+		// Any arguments after that are reached once it stops the greedy run.
+		if greedy != nil && !v.Remainder && (sameTransformer(greedy.Function, StringTransformer) || sameTransformer(greedy.Function, v.Function)) {
+			return arg + " can never be reached since the greedy argument before it will consume it"
+		}
+		greedy = nil
+		if v.Greedy {
+			greedy = &Transformers[i]
+		}
+	}
+	return ""
+}
+
+// Checks the argument transformers and sub-command names of the command and any sub-commands.
+// This returns a *InvalidArgTransformers or *CommandConflict error for the first problem.
+func checkCommand(Path string, c CommandInterface) error {
+	if problem := validateArgTransformers(c.GetArgTransformers()); problem != "" {
+		return &InvalidArgTransformers{Path: Path, Problem: problem}
+	}
+	if g, ok := c.(*CommandGroup); ok {
+		if len(g.conflicts) != 0 {
+			return g.conflicts[0]
+		}
+		for _, v := range g.GetSubcommands() {
+			if err := checkCommand(Path+" "+v.GetName(), v); err != nil {
+				return err
+			}
+		}
+		if g.NoCommandSpecified != nil {
+			return checkCommand(Path+" (no command specified)", g.NoCommandSpecified)
+		}
+	}
+	return nil
+}
+
+// Checks that the names and aliases of the commands in the group are only used by one command.
+func validateGroupNames(Path string, g *CommandGroup, cmds []CommandInterface) []string {
+	problems := make([]string, 0)
+	for _, v := range cmds {
+		keys, localised := commandKeys(v)
+		for _, k := range keys {
+			if existing := g.subcommands[k]; existing != nil && existing != v {
+				problems = append(problems, Path+": the name \""+k+"\" is used by both \""+v.GetName()+"\" and \""+existing.GetName()+"\"")
+			}
+		}
+		for locale, names := range localised {
+			for _, k := range names {
+				if existing := g.localisedSubcommands[locale][k]; existing != nil && existing != v {
+					problems = append(problems, Path+": the name \""+k+"\" in the locale \""+locale+"\" is used by both \""+v.GetName()+"\" and \""+existing.GetName()+"\"")
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// Validates the command and any sub-commands, returning the problems.
+func validateCommand(Path string, c CommandInterface) []string {
+	problems := make([]string, 0)
+	if cmd, ok := c.(*Command); ok && cmd.Function == nil {
+		problems = append(problems, Path+": the command has no function")
+	}
+	if problem := validateArgTransformers(c.GetArgTransformers()); problem != "" {
+		problems = append(problems, Path+": "+problem)
+	}
+	if g, ok := c.(*CommandGroup); ok {
+		subcommands := g.GetSubcommands()
+		sort.Slice(subcommands, func(i, j int) bool {
+			return subcommands[i].GetName() < subcommands[j].GetName()
+		})
+		for _, v := range g.conflicts {
+			name := "the name \"" + v.Name + "\""
+			if v.Locale != "" {
+				name += " in the locale \"" + v.Locale + "\""
+			}
+			problems = append(problems, Path+": "+name+" is used by both \""+v.Command.GetName()+"\" and \""+v.Existing.GetName()+"\"")
+		}
+		problems = append(problems, validateGroupNames(Path, g, subcommands)...)
+		for _, v := range subcommands {
+			problems = append(problems, validateCommand(Path+" "+v.GetName(), v)...)
+		}
+		if g.NoCommandSpecified != nil {
+			problems = append(problems, validateCommand(Path+" (no command specified)", g.NoCommandSpecified)...)
+		}
+	}
+	return problems
+}
+
+// Validate is used to check all of the commands in the router, including the sub-commands of command groups at any depth.
+// This checks for names and aliases which are used by more than one command, argument transformers in an invalid order and commands without a function.
+// If there are any problems, a *CommandValidationError is returned.
+func (r *Router) Validate() error {
+	cmds := r.GetAllCommands()
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].GetName() < cmds[j].GetName()
+	})
+	problems := make([]string, 0)
+	for _, v := range cmds {
+		problems = append(problems, validateCommand(v.GetName(), v)...)
+	}
+	if len(problems) != 0 {
+		return &CommandValidationError{Problems: problems}
+	}
+	return nil
+}
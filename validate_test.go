@@ -0,0 +1,145 @@
+package gommand
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidate is used to test validating the commands in the router.
+func TestValidate(t *testing.T) {
+	r := NewRouter(&RouterConfig{})
+	if err := r.Validate(); err != nil {
+		t.Fatal("the default router should be valid:", err)
+	}
+
+	// Commands with capitals in their name should still be listed.
+	if err := r.TrySetCommand(noopCommand("Ping")); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, v := range r.GetAllCommands() {
+		if v.GetName() == "Ping" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("the command should be in all of the commands")
+	}
+
+	// Add commands with problems.
+	r.SetCommand(&Command{Name: "nofunc"})
+	inner := &CommandGroup{Name: "inner"}
+	inner.AddCommand(noopCommand("a", "x"))
+	outer := &CommandGroup{Name: "outer", NoCommandSpecified: &Command{Name: "default"}}
+	outer.AddCommand(inner)
+	r.SetCommand(outer)
+
+	// Commands added to groups after the group is set are not checked until Validate is called.
+	inner.AddCommand(noopCommand("b", "x"))
+	inner.AddCommand(&Command{
+		Name:            "rest",
+		ArgTransformers: []ArgTransformer{{Function: StringTransformer, Remainder: true}, {Function: StringTransformer}},
+		Function: func(ctx *Context) error {
+			return nil
+		},
+	})
+	err, ok := r.Validate().(*CommandValidationError)
+	if !ok {
+		t.Fatal("expected a validation error")
+	}
+	expected := []string{
+		"nofunc: the command has no function",
+		"outer inner: the name \"x\" is used by both \"b\" and \"a\"",
+		"outer inner rest: argument 1 is a remainder but is not the last argument",
+		"outer (no command specified): the command has no function",
+	}
+	if strings.Join(err.Problems, "\n") != strings.Join(expected, "\n") {
+		t.Fatal("unexpected problems:", err.Problems)
+	}
+
+	// Check the argument transformers are validated when the command is set.
+	for _, v := range []struct {
+		args     []ArgTransformer
+		expected string
+	}{
+		{
+			[]ArgTransformer{{Function: StringTransformer, Optional: true}, {Function: StringTransformer}},
+			"argument 2 is required but follows an optional argument",
+		},
+		{
+			[]ArgTransformer{{Function: StringTransformer, Remainder: true, Optional: true}, {Function: StringTransformer, Optional: true}},
+			"argument 1 is a remainder but is not the last argument",
+		},
+		{
+			[]ArgTransformer{{Function: UserTransformer, Greedy: true}, {Function: UserTransformer}},
+			"argument 2 can never be reached since the greedy argument before it will consume it",
+		},
+		{
+			[]ArgTransformer{{Function: StringTransformer, Greedy: true}, {Function: IntTransformer}},
+			"argument 2 can never be reached since the greedy argument before it will consume it",
+		},
+		{
+			[]ArgTransformer{{Function: UserTransformer, Greedy: true}, {Function: StringTransformer, Remainder: true}},
+			"",
+		},
+		{
+			[]ArgTransformer{{Function: IntTransformer, Greedy: true}, {Function: StringTransformer}, {Function: IntTransformer}},
+			"",
+		},
+	} {
+		cmd := &Command{
+			Name:            "args",
+			ArgTransformers: v.args,
+			Function: func(ctx *Context) error {
+				return nil
+			},
+		}
+		err := r.TrySetCommand(cmd)
+		if v.expected == "" {
+			if err != nil {
+				t.Fatal("the arguments should be valid:", err)
+			}
+			continue
+		}
+		e, ok := err.(*InvalidArgTransformers)
+		if !ok || e.Path != "args" || e.Problem != v.expected {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+	group := &CommandGroup{Name: "group"}
+	group.AddCommand(&Command{Name: "sub", ArgTransformers: []ArgTransformer{{Function: StringTransformer, Remainder: true, Greedy: true}}})
+	if e, ok := r.TrySetCommand(group).(*InvalidArgTransformers); !ok || e.Path != "group sub" || r.GetCommand("group") != nil {
+		t.Fatal("the sub-commands should be checked")
+	}
+
+	// Sub-commands with the same name should not replace each other, and the group should not be set.
+	duplicate := &CommandGroup{Name: "duplicate"}
+	first := noopCommand("sub")
+	duplicate.AddCommand(first)
+	duplicate.AddCommand(noopCommand("Sub"))
+	if duplicate.GetCommand("sub") != first {
+		t.Fatal("the first sub-command should be kept")
+	}
+	if e, ok := r.TrySetCommand(duplicate).(*CommandConflict); !ok || e.Name != "sub" || e.Existing != first || r.GetCommand("duplicate") != nil {
+		t.Fatal("expected a sub-command conflict, got", e)
+	}
+
+	// SetCommand should log the error.
+	logger := &recordingLogger{}
+	logged := NewRouter(&RouterConfig{Logger: logger})
+	logged.SetCommand(noopCommand("a", "x"))
+	logged.SetCommand(noopCommand("b", "x"))
+	if len(logger.lines) != 1 || logger.lines[0] != "error Failed to set the command." || logged.GetCommand("b") != nil {
+		t.Fatal("the conflict was not logged:", logger.lines)
+	}
+
+	// Check the strict mode panics on conflicts.
+	strict := NewRouter(&RouterConfig{StrictCommands: true})
+	strict.SetCommand(noopCommand("a", "x"))
+	defer func() {
+		if _, ok := recover().(*CommandConflict); !ok {
+			t.Fatal("expected a conflict panic")
+		}
+	}()
+	strict.SetCommand(noopCommand("b", "x"))
+}
@@ -0,0 +1,250 @@
+package gommand
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// WaitManager is used to manage waiting within the context.
+type WaitManager struct {
+	ctx *Context
+}
+
+// WithTimeout is used to create a context for waiting which is done once the duration has passed on the router's clock.
+// Use this rather than context.WithTimeout so that the deadline can be controlled in tests.
+func (w *WaitManager) WithTimeout(d time.Duration) (context.Context, context.CancelFunc) {
+	return withClockTimeout(w.ctx.Router.clock(), context.Background(), d)
+}
+
+// This is used to pass an event to something waiting for it.
+// Events are queued rather than sent straight to the consumer, meaning a slow consumer does not hold up the gateway handler or anything else waiting for the event.
+type eventWaiter struct {
+	check   func(evt interface{}) bool
+	lock    sync.Mutex
+	queue   []interface{}
+	pending chan struct{}
+}
+
+// Creates a waiter which uses the check function given.
+func newEventWaiter(check func(evt interface{}) bool) *eventWaiter {
+	return &eventWaiter{check: check, pending: make(chan struct{}, 1)}
+}
+
+// Adds the event to the queue without blocking.
+func (w *eventWaiter) emit(evt interface{}) {
+	w.lock.Lock()
+	w.queue = append(w.queue, evt)
+	w.lock.Unlock()
+	select {
+	case w.pending <- struct{}{}:
+	default:
+	}
+}
+
+// Takes all of the events from the queue.
+func (w *eventWaiter) take() []interface{} {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	events := w.queue
+	w.queue = nil
+	return events
+}
+
+// This is used to dispatch events from a session to everything waiting for them.
+// One gateway handler is registered for each event the first time it is waited for. Since disgord can only remove handlers when their event is dispatched, waiters are added and removed here instead, meaning they are removed as soon as they are done.
+// Once nothing is waiting, the dispatcher is closed and forgotten, and its gateway handlers are removed by disgord the next time their event is dispatched.
+type waitDispatcher struct {
+	lock    sync.RWMutex
+	waiters map[string]map[*eventWaiter]struct{}
+	closed  int32
+}
+
+// The handler controller for the gateway handlers of a wait dispatcher. The handlers die when the dispatcher is closed.
+type waitDispatcherCtrl struct {
+	d *waitDispatcher
+}
+
+func (c waitDispatcherCtrl) OnInsert(disgord.Session) error { return nil }
+func (c waitDispatcherCtrl) OnRemove(disgord.Session) error { return nil }
+func (c waitDispatcherCtrl) Update()                        {}
+
+// IsDead is used to check if the dispatcher is closed.
+func (c waitDispatcherCtrl) IsDead() bool {
+	return atomic.LoadInt32(&c.d.closed) == 1
+}
+
+// This is used to hold the wait dispatcher for each session which has something waiting.
+var waitDispatchers = map[disgord.Session]*waitDispatcher{}
+
+// This is the thread lock for the wait dispatchers. This must be locked before the lock of a dispatcher.
+var waitDispatchersLock = sync.Mutex{}
+
+// Adds the waiter to each of the events for the session, returning the dispatcher it was added to.
+// A gateway handler is registered for any event which has not been waited for by the dispatcher.
+func addWaiter(s disgord.Session, w *eventWaiter, Events []string) *waitDispatcher {
+	waitDispatchersLock.Lock()
+	defer waitDispatchersLock.Unlock()
+	d := waitDispatchers[s]
+	if d == nil {
+		d = &waitDispatcher{waiters: map[string]map[*eventWaiter]struct{}{}}
+		waitDispatchers[s] = d
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, v := range Events {
+		waiters, ok := d.waiters[v]
+		if !ok {
+			waiters = map[*eventWaiter]struct{}{}
+			d.waiters[v] = waiters
+			evtName := v
+			eventRegistrators[v](s.Gateway().WithCtrl(waitDispatcherCtrl{d: d}), func(evt interface{}) {
+				d.dispatch(evtName, evt)
+			})
+		}
+		waiters[w] = struct{}{}
+	}
+	return d
+}
+
+// Removes the waiter from each of the events, closing the dispatcher if nothing else is waiting.
+func (d *waitDispatcher) remove(s disgord.Session, w *eventWaiter, Events []string) {
+	waitDispatchersLock.Lock()
+	defer waitDispatchersLock.Unlock()
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, v := range Events {
+		delete(d.waiters[v], w)
+	}
+	for _, v := range d.waiters {
+		if len(v) != 0 {
+			return
+		}
+	}
+	atomic.StoreInt32(&d.closed, 1)
+	if waitDispatchers[s] == d {
+		delete(waitDispatchers, s)
+	}
+}
+
+// Passes the event to everything waiting for it.
+func (d *waitDispatcher) dispatch(evtName string, evt interface{}) {
+	d.lock.RLock()
+	waiters := make([]*eventWaiter, 0, len(d.waiters[evtName]))
+	for w := range d.waiters[evtName] {
+		waiters = append(waiters, w)
+	}
+	d.lock.RUnlock()
+	for _, w := range waiters {
+		if w.check(evt) {
+			w.emit(evt)
+		}
+	}
+}
+
+// EventStream is a stream of events which are being collected.
+// The events channel is closed when the maximum number of events is reached, the context is done or Cancel is called.
+type EventStream struct {
+	events chan interface{}
+	cancel context.CancelFunc
+}
+
+// Events is used to get the channel which the events are sent to.
+func (s *EventStream) Events() <-chan interface{} {
+	return s.events
+}
+
+// Cancel is used to stop collecting events. The stream stops receiving events immediately.
+func (s *EventStream) Cancel() {
+	s.cancel()
+}
+
+// All is used to block until the stream ends, returning all of the events which were collected.
+func (s *EventStream) All() []interface{} {
+	a := make([]interface{}, 0)
+	for evt := range s.events {
+		a = append(a, evt)
+	}
+	return a
+}
+
+// Collects events with specific conditions from the gateway. Max being 0 means there is no limit on the number of events.
+// You should NOT block during the check function.
+func collectEvents(ctx context.Context, s disgord.Session, Max int, CheckFunc func(s disgord.Session, evt interface{}) bool, Events ...string) (*EventStream, error) {
+	// Check the events can be waited for.
+	if len(Events) == 0 {
+		return nil, errors.New("no events specified")
+	}
+	for _, v := range Events {
+		if _, ok := eventRegistrators[v]; !ok {
+			return nil, errors.New("unknown event: " + v)
+		}
+	}
+
+	// Create the stream.
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &EventStream{events: make(chan interface{}), cancel: cancel}
+	waiter := newEventWaiter(func(evt interface{}) bool {
+		return ctx.Err() == nil && CheckFunc(s, evt)
+	})
+
+	// Start waiting for the events. This is done before the stream can end so the waiter is always removed.
+	d := addWaiter(s, waiter, Events)
+
+	// Handles forwarding events to the stream until it is done.
+	go func() {
+		defer func() {
+			d.remove(s, waiter, Events)
+			cancel()
+			close(stream.events)
+		}()
+		count := 0
+		for {
+			select {
+			case <-waiter.pending:
+				for _, evt := range waiter.take() {
+					select {
+					case stream.events <- evt:
+					case <-ctx.Done():
+						return
+					}
+					count++
+					if Max > 0 && count >= Max {
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stream, nil
+}
+
+// Collect is used to collect events with specific conditions from any of the event names specified (such as disgord.EvtMessageCreate).
+// Max is the maximum number of events to collect, with 0 meaning events will be collected until the context is done or the stream is cancelled.
+// You should NOT block during the check function.
+func (w *WaitManager) Collect(ctx context.Context, Max int, CheckFunc func(s disgord.Session, evt interface{}) bool, Events ...string) (*EventStream, error) {
+	return collectEvents(ctx, w.ctx.Session, Max, CheckFunc, Events...)
+}
+
+// WaitForAny is used to wait for the first event with specific conditions from any of the event names specified (such as disgord.EvtMessageCreate).
+// If the context is done before an event is received, this will be nil. You should NOT block during the check function.
+func (w *WaitManager) WaitForAny(ctx context.Context, CheckFunc func(s disgord.Session, evt interface{}) bool, Events ...string) (interface{}, error) {
+	stream, err := w.Collect(ctx, 1, CheckFunc, Events...)
+	if err != nil {
+		return nil, err
+	}
+	return <-stream.Events(), nil
+}
+
+// This allows you to wait for a event with specific conditions. You should NOT block during the check function.
+func (w *WaitManager) waitForEvent(ctx context.Context, EventName string, CheckFunc func(s disgord.Session, evt interface{}) bool) interface{} {
+	// The event names passed through here are generated, so this will never error.
+	evt, _ := w.WaitForAny(ctx, CheckFunc, EventName)
+	return evt
+}
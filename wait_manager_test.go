@@ -0,0 +1,222 @@
+package gommand
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andersfylling/disgord"
+)
+
+// Used to register handlers in the tests without a gateway connection.
+type testGateway struct {
+	disgord.GatewayQueryBuilder
+	session *testWaitSession
+	ctrl    disgord.HandlerCtrl
+}
+
+func (g *testGateway) WithCtrl(ctrl disgord.HandlerCtrl) disgord.SocketHandlerRegistrator {
+	return &testGateway{session: g.session, ctrl: ctrl}
+}
+
+func (g *testGateway) add(evtName string, handler func(evt interface{})) {
+	g.session.lock.Lock()
+	defer g.session.lock.Unlock()
+	g.session.handlers[evtName] = append(g.session.handlers[evtName], testHandler{ctrl: g.ctrl, handler: handler})
+}
+
+func (g *testGateway) MessageCreate(handler disgord.HandlerMessageCreate, _ ...disgord.HandlerMessageCreate) {
+	g.add(disgord.EvtMessageCreate, func(evt interface{}) { handler(g.session, evt.(*disgord.MessageCreate)) })
+}
+
+func (g *testGateway) MessageReactionAdd(handler disgord.HandlerMessageReactionAdd, _ ...disgord.HandlerMessageReactionAdd) {
+	g.add(disgord.EvtMessageReactionAdd, func(evt interface{}) { handler(g.session, evt.(*disgord.MessageReactionAdd)) })
+}
+
+// A handler registered with the test gateway.
+type testHandler struct {
+	ctrl    disgord.HandlerCtrl
+	handler func(evt interface{})
+}
+
+// Checks if the handler has been killed by its controller.
+func (h testHandler) dead() bool {
+	return h.ctrl != nil && h.ctrl.IsDead()
+}
+
+// Used as a session which can dispatch events in the tests.
+type testWaitSession struct {
+	disgord.Session
+	lock     sync.Mutex
+	handlers map[string][]testHandler
+}
+
+// Creates a session which can dispatch events in the tests.
+func newTestWaitSession() *testWaitSession {
+	return &testWaitSession{handlers: map[string][]testHandler{}}
+}
+
+// Gets the number of gateway handlers registered for the event which are not dead.
+func (s *testWaitSession) registered(evtName string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	n := 0
+	for _, v := range s.handlers[evtName] {
+		if !v.dead() {
+			n++
+		}
+	}
+	return n
+}
+
+// Gets the number of things waiting for the event.
+func (s *testWaitSession) waiting(evtName string) int {
+	waitDispatchersLock.Lock()
+	d := waitDispatchers[s]
+	waitDispatchersLock.Unlock()
+	if d == nil {
+		return 0
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return len(d.waiters[evtName])
+}
+
+func (s *testWaitSession) Gateway() disgord.GatewayQueryBuilder {
+	return &testGateway{session: s}
+}
+
+// Dispatches the event to the gateway handlers, removing any which are dead like disgord does.
+func (s *testWaitSession) dispatch(evtName string, evt interface{}) {
+	s.lock.Lock()
+	handlers := s.handlers[evtName]
+	s.lock.Unlock()
+	for _, v := range handlers {
+		if !v.dead() {
+			v.handler(evt)
+		}
+	}
+	s.lock.Lock()
+	alive := make([]testHandler, 0, len(s.handlers[evtName]))
+	for _, v := range s.handlers[evtName] {
+		if !v.dead() {
+			alive = append(alive, v)
+		}
+	}
+	s.handlers[evtName] = alive
+	s.lock.Unlock()
+}
+
+// TestWaitManager is used to test waiting for and collecting events.
+func TestWaitManager(t *testing.T) {
+	s := newTestWaitSession()
+	w := &WaitManager{ctx: &Context{Session: s}}
+
+	// Unknown events should error rather than panic.
+	if _, err := w.Collect(context.Background(), 1, func(disgord.Session, interface{}) bool { return true }, "UNKNOWN"); err == nil {
+		t.Fatal("unknown event did not error")
+	}
+
+	// Waits until nothing is waiting for the event.
+	waitUntilRemoved := func(evtName string) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for s.waiting(evtName) != 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("waiter was not removed for", evtName)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Wait for the first of several events.
+	res := make(chan interface{})
+	go func() {
+		evt, _ := w.WaitForAny(context.Background(), func(_ disgord.Session, evt interface{}) bool {
+			_, ok := evt.(*disgord.MessageReactionAdd)
+			return ok
+		}, disgord.EvtMessageCreate, disgord.EvtMessageReactionAdd)
+		res <- evt
+	}()
+	for s.waiting(disgord.EvtMessageReactionAdd) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{})
+	s.dispatch(disgord.EvtMessageReactionAdd, &disgord.MessageReactionAdd{})
+	if _, ok := (<-res).(*disgord.MessageReactionAdd); !ok {
+		t.Fatal("wrong event received")
+	}
+	waitUntilRemoved(disgord.EvtMessageReactionAdd)
+	waitUntilRemoved(disgord.EvtMessageCreate)
+
+	// Collect a number of events.
+	stream, err := w.Collect(context.Background(), 2, func(disgord.Session, interface{}) bool { return true }, disgord.EvtMessageCreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for i := 0; i < 3; i++ {
+			s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{})
+		}
+	}()
+	if len(stream.All()) != 2 {
+		t.Fatal("wrong number of events collected")
+	}
+
+	// Cancelling should remove the waiter without an event being dispatched.
+	stream, _ = w.Collect(context.Background(), 0, func(disgord.Session, interface{}) bool { return true }, disgord.EvtMessageCreate)
+	stream.Cancel()
+	if len(stream.All()) != 0 {
+		t.Fatal("events were collected after cancelling")
+	}
+	waitUntilRemoved(disgord.EvtMessageCreate)
+
+	// Only one gateway handler should be registered for each event, however many things are waiting for it.
+	streams := make([]*EventStream, 0, 3)
+	for i := 0; i < 3; i++ {
+		stream, _ = w.Collect(context.Background(), 0, func(disgord.Session, interface{}) bool { return true }, disgord.EvtMessageCreate)
+		streams = append(streams, stream)
+	}
+	if s.registered(disgord.EvtMessageCreate) != 1 {
+		t.Fatal("gateway handlers were registered more than once")
+	}
+
+	// A consumer which does not read should not stop the others from receiving events.
+	done := make(chan struct{})
+	go func() {
+		s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{})
+		s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{})
+		close(done)
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-streams[1].Events():
+		case <-time.After(time.Second):
+			t.Fatal("the event was not received")
+		}
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatching was blocked by a consumer")
+	}
+	for _, v := range streams {
+		v.Cancel()
+		v.All()
+	}
+	waitUntilRemoved(disgord.EvtMessageCreate)
+
+	// Once nothing is waiting, the dispatcher is forgotten and its handlers are removed on the next dispatch.
+	waitDispatchersLock.Lock()
+	_, ok := waitDispatchers[s]
+	waitDispatchersLock.Unlock()
+	if ok {
+		t.Fatal("the dispatcher was not removed")
+	}
+	s.dispatch(disgord.EvtMessageCreate, &disgord.MessageCreate{})
+	s.dispatch(disgord.EvtMessageReactionAdd, &disgord.MessageReactionAdd{})
+	if len(s.handlers[disgord.EvtMessageCreate]) != 0 || len(s.handlers[disgord.EvtMessageReactionAdd]) != 0 {
+		t.Fatal("gateway handlers were not removed")
+	}
+}